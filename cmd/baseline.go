@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/Marcel2603/tfcoach/cmd/config"
+	"github.com/Marcel2603/tfcoach/internal/engine/processor"
+	"github.com/Marcel2603/tfcoach/internal/runner"
+	"github.com/Marcel2603/tfcoach/rules/core"
+	"github.com/spf13/cobra"
+)
+
+var baselineFileFlag string
+
+var baselineCmd = &cobra.Command{
+	Use:   "baseline",
+	Short: "Manage the baseline of accepted issues",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return cmd.Help()
+	},
+}
+
+var baselineCreateCmd = &cobra.Command{
+	Use:   "create [path]",
+	Short: "Record all current issues in a baseline, so that lint only reports new ones",
+	Args:  cobra.MaximumNArgs(1),
+	PreRunE: func(cmd *cobra.Command, _ []string) error {
+		err := config.ParseStandardFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		if cmd.Flags().Changed("include-terragrunt-cache") {
+			config.OverrideIncludeTgCache(includeTgCacheFlag)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target := "."
+		if len(args) > 0 {
+			target = args[0]
+		}
+
+		enabledRules := core.EnabledRules()
+		ruleResolver, err := newRuleResolver(enabledRules)
+		if err != nil {
+			return err
+		}
+
+		code := runner.CreateBaseline(target, newSource(), enabledRules, ruleResolver, cmd.OutOrStdout(), baselineFileFlag)
+		os.Exit(code)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(baselineCmd)
+	baselineCmd.AddCommand(baselineCreateCmd)
+	config.AddStandardFlags(baselineCreateCmd)
+
+	baselineCreateCmd.Flags().BoolVar(
+		&includeTgCacheFlag,
+		"include-terragrunt-cache",
+		config.GetOutputConfiguration().IncludeTerragruntCache.IsTrue,
+		"Include Terragrunt cache in scanned files",
+	)
+
+	baselineCreateCmd.Flags().StringVar(
+		&baselineFileFlag,
+		"baseline",
+		processor.DefaultBaselineFileName,
+		"Baseline file to write",
+	)
+
+	baselineCmd.Annotations = map[string]string{
+		"exitCodes": "0:OK",
+	}
+	baselineCreateCmd.Annotations = map[string]string{
+		"exitCodes": "0:Baseline written,2:Runtime error",
+	}
+}
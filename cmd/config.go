@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Marcel2603/tfcoach/cmd/config"
+	"github.com/Marcel2603/tfcoach/internal/formatter"
+	"github.com/Marcel2603/tfcoach/rules/core"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Validate configuration files and show the effective configuration",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return cmd.Help()
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate [file]",
+	Short: "Check a configuration file for unknown keys, unknown rules and invalid values",
+	Long: `Check a configuration file for unknown keys, unknown rules and invalid values.
+Without file, the configuration file in the current directory is checked.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var configPath string
+		if len(args) > 0 {
+			configPath = args[0]
+		} else if localConfigPath, found := config.FindLocalConfigFile(""); found {
+			configPath = localConfigPath
+		} else {
+			return errors.New("no config file found in the current directory")
+		}
+
+		valid, err := writeValidation(configPath, cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+		if !valid {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the effective configuration and the source of every value",
+	Args:  cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, _ []string) error {
+		return config.ParseStandardFlags(cmd)
+	},
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return writeEffectiveConfiguration(cmd.OutOrStdout())
+	},
+}
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of configuration files",
+	Long: `Print the JSON Schema of configuration files, which editors use for autocompletion and validation.
+The schema of the latest version is published, reference it in the first line of .tfcoach.yml:
+
+    # yaml-language-server: $schema=` + config.ConfigSchemaURL,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		schema, err := config.JSONSchema(core.All())
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), string(schema))
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configSchemaCmd)
+	config.AddStandardFlags(configShowCmd)
+
+	configValidateCmd.Annotations = map[string]string{
+		"exitCodes": "0:Valid configuration,1:Invalid configuration or file not found",
+	}
+}
+
+// writeValidation writes the problems of a configuration file. The result is true if there are none.
+func writeValidation(configPath string, w io.Writer) (bool, error) {
+	problems, err := config.ValidateFile(configPath)
+	if err != nil {
+		return false, err
+	}
+	if len(problems) == 0 {
+		_, err = fmt.Fprintf(w, "%s is valid\n", configPath)
+		return true, err
+	}
+	for _, problem := range problems {
+		_, _ = fmt.Fprintln(w, problem)
+	}
+	_, err = fmt.Fprintf(w, "%d problem%s found\n", len(problems), formatter.CondPlural(len(problems)))
+	return false, err
+}
+
+// writeEffectiveConfiguration writes the loaded layers and the merged configuration as YAML, with the source of every
+// value as comment.
+func writeEffectiveConfiguration(w io.Writer) error {
+	for _, layer := range config.GetLayers() {
+		switch {
+		case layer.Err != nil && layer.Path == "":
+			_, _ = fmt.Fprintf(w, "# %s: not found\n", layer.Source)
+		case layer.Err != nil:
+			_, _ = fmt.Fprintf(w, "# %s: %s (not used: %v)\n", layer.Source, layer.Path, layer.Err)
+		case layer.Path != "":
+			_, _ = fmt.Fprintf(w, "# %s: %s\n", layer.Source, layer.Path)
+		default:
+			_, _ = fmt.Fprintf(w, "# %s\n", layer.Source)
+		}
+	}
+	_, _ = fmt.Fprintf(w, "# %s\n", config.SourceFlag)
+
+	var node yaml.Node
+	if err := node.Encode(config.GetEffectiveConfiguration()); err != nil {
+		return err
+	}
+	annotateSources(&node, "", config.Provenance())
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// annotateSources adds the source of every value as line comment. Values without source come from the defaults.
+func annotateSources(node *yaml.Node, path string, provenance map[string]string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		valuePath := key.Value
+		if path != "" {
+			valuePath = path + "." + key.Value
+		}
+
+		source, found := provenance[valuePath]
+		if !found && value.Kind == yaml.MappingNode && len(value.Content) > 0 {
+			annotateSources(value, valuePath, provenance)
+			continue
+		}
+		if !found {
+			source = config.SourceDefault
+		}
+		if value.Kind == yaml.ScalarNode || len(value.Content) == 0 {
+			value.LineComment = source
+		} else {
+			key.LineComment = source
+		}
+	}
+}
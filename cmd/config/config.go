@@ -4,59 +4,356 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
 	"reflect"
 	"slices"
+	"strconv"
+	"strings"
 
+	"github.com/Marcel2603/tfcoach/internal/constants"
+	"github.com/Marcel2603/tfcoach/internal/types"
+	"github.com/codeglyph/go-dotignore/v2"
 	"gopkg.in/yaml.v3"
 )
 
+const templateFormat = "template"
+
 var (
-	supportedOutputFormats = []string{"json", "compact", "pretty", "educational"}
+	supportedFailOnValues = []string{"high", "medium", "low", "info", "never"}
+
+	supportedOutputFormats = []string{
+		"json",
+		"compact",
+		"pretty",
+		"educational",
+		"sarif",
+		"junit",
+		"gitlab",
+		"github",
+		"checkstyle",
+		"html",
+		"markdown",
+		templateFormat,
+	}
 )
 
+// ruleOptions holds the options of all known rules, see RegisterRuleOptions
+var ruleOptions = make(map[string][]types.RuleOption)
+
 type NullableBool struct {
 	IsTrue   bool
 	HasValue bool
 }
 
 type config struct {
-	Rules  map[string]RuleConfiguration `json:"rules" yaml:"rules"`
-	Output OutputConfiguration          `json:"output" yaml:"output"`
+	// Schema references the JSON Schema of the file, for editors.
+	Schema string `json:"$schema,omitempty" yaml:"$schema,omitempty"`
+	// Extends lists the presets and files this configuration is merged over, it is empty once resolved.
+	Extends   []string            `json:"extends,omitempty" yaml:"extends,omitempty"`
+	Rules     RuleConfigurations  `json:"rules" yaml:"rules"`
+	Overrides []RuleOverride      `json:"overrides" yaml:"overrides"`
+	Output    OutputConfiguration `json:"output" yaml:"output"`
 }
 
+// RuleConfigurations maps rule IDs to their configuration.
+type RuleConfigurations map[string]RuleConfiguration
+
 type RuleConfiguration struct {
-	Enabled bool              `json:"enabled" yaml:"enabled" default:"true"`
-	Spec    map[string]string `json:"spec" yaml:"spec"`
+	Enabled  NullableBool   `json:"enabled" yaml:"enabled,omitempty"`
+	Severity string         `json:"severity" yaml:"severity,omitempty"`
+	Spec     map[string]any `json:"spec" yaml:"spec,omitempty"`
+}
+
+// RuleOverride changes the configuration of rules for the files matching one of its patterns. The patterns use the
+// .gitignore syntax and are relative to the directory of the configuration file.
+type RuleOverride struct {
+	Files []string                            `json:"files" yaml:"files"`
+	Rules map[string]PartialRuleConfiguration `json:"rules" yaml:"rules"`
+}
+
+// PartialRuleConfiguration changes the parts of a rule configuration that are set. Its spec is merged into the spec
+// of the rule.
+type PartialRuleConfiguration struct {
+	Enabled NullableBool   `json:"enabled" yaml:"enabled"`
+	Spec    map[string]any `json:"spec" yaml:"spec"`
 }
 
 type OutputConfiguration struct {
-	Format                 string       `json:"format" yaml:"format"`
-	Color                  NullableBool `json:"color" yaml:"color"`
-	Emojis                 NullableBool `json:"emojis" yaml:"emojis"`
-	IncludeTerragruntCache NullableBool `json:"include_terragrunt_cache" yaml:"include_terragrunt_cache"`
+	Format                 string         `json:"format" yaml:"format"`
+	Color                  NullableBool   `json:"color" yaml:"color"`
+	Emojis                 NullableBool   `json:"emojis" yaml:"emojis"`
+	IncludeTerragruntCache NullableBool   `json:"include_terragrunt_cache" yaml:"include_terragrunt_cache"`
+	Targets                []OutputTarget `json:"targets" yaml:"targets"`
+	Template               string         `json:"template" yaml:"template"`
+	FailOn                 string         `json:"fail_on" yaml:"fail_on"`
+	MaxIssues              int            `json:"max_issues" yaml:"max_issues"`
+}
+
+// OutputTarget is one report written by a single lint run. An empty Path writes to stdout.
+type OutputTarget struct {
+	Format   string       `json:"format" yaml:"format"`
+	Path     string       `json:"path" yaml:"path"`
+	Color    NullableBool `json:"color" yaml:"color"`
+	Emojis   NullableBool `json:"emojis" yaml:"emojis"`
+	Template string       `json:"template" yaml:"template"`
+}
+
+// valueError is an invalid value of the configuration, path being the keys and list indexes that lead to it, so that
+// "tfcoach config validate" can show where it is.
+type valueError struct {
+	path []string
+	err  error
+}
+
+func (e valueError) Error() string {
+	return e.err.Error()
+}
+
+func (e valueError) Unwrap() error {
+	return e.err
+}
+
+func errorAt(err error, path ...string) error {
+	return valueError{path: path, err: err}
 }
 
 func (c *config) Validate() error {
-	var errs []error
+	return errors.Join(c.validate()...)
+}
+
+// validate returns the errors of Validate one by one.
+func (c *config) validate() []error {
+	errs := c.Rules.validate()
+	errs = append(errs, validateOverrides(c.Overrides)...)
+
 	if !slices.Contains(supportedOutputFormats, c.Output.Format) {
-		errs = append(errs, fmt.Errorf("invalid format: %q (supported: %v)", c.Output.Format, supportedOutputFormats))
+		err := fmt.Errorf("invalid format: %q (supported: %v)", c.Output.Format, supportedOutputFormats)
+		errs = append(errs, errorAt(err, "output", "format"))
+	}
+
+	for i, target := range c.Output.Targets {
+		index := strconv.Itoa(i)
+		if !slices.Contains(supportedOutputFormats, target.Format) {
+			err := fmt.Errorf("invalid target format: %q (supported: %v)", target.Format, supportedOutputFormats)
+			errs = append(errs, errorAt(err, "output", "targets", index, "format"))
+		}
+		if target.Format == templateFormat && target.Template == "" && c.Output.Template == "" {
+			err := fmt.Errorf("invalid target %d: format %q requires a template", i, templateFormat)
+			errs = append(errs, errorAt(err, "output", "targets", index))
+		}
+	}
+	// targets replace format, see ResolvedTargets
+	if len(c.Output.Targets) == 0 && c.Output.Format == templateFormat && c.Output.Template == "" {
+		err := fmt.Errorf("invalid format: %q requires a template", templateFormat)
+		errs = append(errs, errorAt(err, "output", "format"))
+	}
+
+	if err := ValidateFailOn(c.Output.FailOn); err != nil {
+		errs = append(errs, errorAt(err, "output", "fail_on"))
+	}
+
+	if c.Output.MaxIssues < 0 {
+		err := fmt.Errorf("invalid max_issues: %d (must not be negative)", c.Output.MaxIssues)
+		errs = append(errs, errorAt(err, "output", "max_issues"))
 	}
 
 	if !c.Output.Color.HasValue {
-		errs = append(errs, fmt.Errorf("invalid color: never set"))
+		errs = append(errs, errorAt(errors.New("invalid color: never set"), "output", "color"))
 	}
 
 	if !c.Output.Emojis.HasValue {
-		errs = append(errs, fmt.Errorf("invalid emojis config: never set"))
+		errs = append(errs, errorAt(errors.New("invalid emojis config: never set"), "output", "emojis"))
+	}
+
+	return errs
+}
+
+// ByID returns the configuration of a rule, which is empty and therefore enabled if it is not configured.
+func (r RuleConfigurations) ByID(ruleID string) RuleConfiguration {
+	return r[ruleID]
+}
+
+// mergedWith returns the rule configurations with the ones of updated merged over them, see
+// RuleConfiguration.mergedWith.
+func (r RuleConfigurations) mergedWith(updated RuleConfigurations) RuleConfigurations {
+	if len(updated) == 0 {
+		return r
+	}
+	merged := maps.Clone(r)
+	if merged == nil {
+		merged = make(RuleConfigurations, len(updated))
+	}
+	for ruleID, ruleConfiguration := range updated {
+		merged[ruleID] = merged[ruleID].mergedWith(ruleConfiguration)
+	}
+	return merged
+}
+
+// IsEnabled reports whether the rule is enabled, which it is unless disabled explicitly.
+func (r RuleConfiguration) IsEnabled() bool {
+	return !r.Enabled.HasValue || r.Enabled.IsTrue
+}
+
+// mergedWith returns the rule configuration with the parts of updated that are set. The spec is merged option by
+// option.
+func (r RuleConfiguration) mergedWith(updated RuleConfiguration) RuleConfiguration {
+	if updated.Enabled.HasValue {
+		r.Enabled = updated.Enabled
+	}
+	if updated.Severity != "" {
+		r.Severity = updated.Severity
+	}
+	if len(updated.Spec) > 0 {
+		spec := maps.Clone(r.Spec)
+		if spec == nil {
+			spec = make(map[string]any, len(updated.Spec))
+		}
+		maps.Copy(spec, updated.Spec)
+		r.Spec = spec
+	}
+	return r
+}
+
+func (r RuleConfigurations) validate() []error {
+	var errs []error
+	for _, ruleID := range slices.Sorted(maps.Keys(r)) {
+		ruleConfiguration := r[ruleID]
+		if severity := ruleConfiguration.Severity; severity != "" {
+			if _, err := constants.ParseSeverity(severity); err != nil {
+				err = fmt.Errorf("invalid severity for rule %s: %w", ruleID, err)
+				errs = append(errs, errorAt(err, "rules", ruleID, "severity"))
+			}
+		}
+		if err := checkRuleID(ruleID); err != nil {
+			errs = append(errs, errorAt(err, "rules", ruleID))
+		}
+		if options, known := ruleOptions[ruleID]; known {
+			if _, err := types.ParseRuleOptions(options, ruleConfiguration.Spec); err != nil {
+				err = fmt.Errorf("invalid spec for rule %s: %w", ruleID, err)
+				errs = append(errs, errorAt(err, "rules", ruleID, "spec"))
+			}
+		}
+	}
+	return errs
+}
+
+// applyTo returns the rule configuration with the parts of the partial configuration that are set.
+func (p PartialRuleConfiguration) applyTo(ruleConfiguration RuleConfiguration) RuleConfiguration {
+	return ruleConfiguration.mergedWith(RuleConfiguration{Enabled: p.Enabled, Spec: p.Spec})
+}
+
+func validateOverrides(overrides []RuleOverride) []error {
+	var errs []error
+	for i, override := range overrides {
+		index := strconv.Itoa(i)
+		if len(override.Files) == 0 {
+			err := fmt.Errorf("invalid override %d: files must not be empty", i+1)
+			errs = append(errs, errorAt(err, "overrides", index))
+		} else if _, err := dotignore.NewPatternMatcher(override.Files); err != nil {
+			errs = append(errs, errorAt(fmt.Errorf("invalid override %d: %w", i+1, err), "overrides", index, "files"))
+		}
+		for _, ruleID := range slices.Sorted(maps.Keys(override.Rules)) {
+			if err := checkRuleID(ruleID); err != nil {
+				errs = append(errs, errorAt(fmt.Errorf("invalid override %d: %w", i+1, err), "overrides", index, "rules", ruleID))
+			}
+			options, known := ruleOptions[ruleID]
+			if !known {
+				continue
+			}
+			if _, err := types.ParseRuleOptions(options, override.Rules[ruleID].Spec); err != nil {
+				err = fmt.Errorf("invalid override %d: invalid spec for rule %s: %w", i+1, ruleID, err)
+				errs = append(errs, errorAt(err, "overrides", index, "rules", ruleID, "spec"))
+			}
+		}
 	}
+	return errs
+}
 
-	return errors.Join(errs...)
+// checkRuleID returns an error if no rule with the ID is registered. Without registered rules, e.g. when the package is
+// used on its own, every ID is accepted. When loading leniently, unknown rules are only logged.
+func checkRuleID(ruleID string) error {
+	if _, known := ruleOptions[ruleID]; known || len(ruleOptions) == 0 {
+		return nil
+	}
+	err := unknownRuleError(ruleID)
+	if lenient {
+		slog.Warn("ignoring configuration of unknown rule", "err", err)
+		return nil
+	}
+	return err
+}
+
+// RegisterRuleOptions makes the options of a rule known, so that its spec gets validated when loading the config.
+func RegisterRuleOptions(ruleID string, options []types.RuleOption) {
+	ruleOptions[ruleID] = options
 }
 
 func SupportedFormats() []string {
 	return slices.Clone(supportedOutputFormats)
 }
 
+func ValidateFailOn(failOn string) error {
+	if !slices.Contains(supportedFailOnValues, failOn) {
+		return fmt.Errorf("invalid fail_on: %q (want %s)", failOn, strings.Join(supportedFailOnValues, "|"))
+	}
+	return nil
+}
+
+// ParseOutputTarget parses the value of an "--output format[=path]" flag.
+func ParseOutputTarget(value string) (OutputTarget, error) {
+	format, path, _ := strings.Cut(value, "=")
+	if !slices.Contains(supportedOutputFormats, format) {
+		return OutputTarget{}, fmt.Errorf("invalid --output: %s (want format[=path] with format one of %s)", value, strings.Join(supportedOutputFormats, "|"))
+	}
+	if path == "-" {
+		path = ""
+	}
+	return OutputTarget{Format: format, Path: path}, nil
+}
+
+// ResolvedTargets returns the configured targets, or a single stdout target built from format, color and emojis if
+// there are none. Color, emojis and template not set on a target are inherited. Files never get colored output.
+func (o OutputConfiguration) ResolvedTargets() []OutputTarget {
+	targets := o.Targets
+	if len(targets) == 0 {
+		targets = []OutputTarget{{Format: o.Format}}
+	}
+
+	resolved := make([]OutputTarget, 0, len(targets))
+	for _, target := range targets {
+		if target.Path != "" {
+			target.Color = NullableBool{HasValue: true, IsTrue: false}
+		} else if !target.Color.HasValue {
+			target.Color = o.Color
+		}
+		if !target.Emojis.HasValue {
+			target.Emojis = o.Emojis
+		}
+		if target.Template == "" {
+			target.Template = o.Template
+		}
+		resolved = append(resolved, target)
+	}
+	return resolved
+}
+
+// MarshalJSON writes the plain bool, or null if it was never set.
+func (nullableBool NullableBool) MarshalJSON() ([]byte, error) {
+	if !nullableBool.HasValue {
+		return []byte("null"), nil
+	}
+	return json.Marshal(nullableBool.IsTrue)
+}
+
+// MarshalYAML writes the plain bool, or null if it was never set.
+func (nullableBool NullableBool) MarshalYAML() (any, error) {
+	if !nullableBool.HasValue {
+		return nil, nil
+	}
+	return nullableBool.IsTrue, nil
+}
+
 func (nullableBool *NullableBool) UnmarshalJSON(b []byte) error {
 	var unmarshalledJSON bool
 
@@ -100,11 +397,23 @@ func (NullableBoolTransformer) Transformer(typ reflect.Type) func(dst, src refle
 	return nil
 }
 
+// OverrideFormat sets the format of a single report written to stdout, the configured targets are dropped.
 func OverrideFormat(format string) {
+	markSetByFlag("output.format")
 	configuration.Output.Format = format
+	if len(configuration.Output.Targets) > 0 {
+		markSetByFlag("output.targets")
+		configuration.Output.Targets = nil
+	}
+}
+
+func OverrideTemplate(templatePath string) {
+	markSetByFlag("output.template")
+	configuration.Output.Template = templatePath
 }
 
 func OverrideColor(allowColor bool) {
+	markSetByFlag("output.color")
 	configuration.Output.Color = NullableBool{
 		HasValue: true,
 		IsTrue:   allowColor,
@@ -112,13 +421,30 @@ func OverrideColor(allowColor bool) {
 }
 
 func OverrideEmojis(allowEmojis bool) {
+	markSetByFlag("output.emojis")
 	configuration.Output.Emojis = NullableBool{
 		HasValue: true,
 		IsTrue:   allowEmojis,
 	}
 }
 
+func OverrideTargets(targets []OutputTarget) {
+	markSetByFlag("output.targets")
+	configuration.Output.Targets = targets
+}
+
+func OverrideFailOn(failOn string) {
+	markSetByFlag("output.fail_on")
+	configuration.Output.FailOn = failOn
+}
+
+func OverrideMaxIssues(maxIssues int) {
+	markSetByFlag("output.max_issues")
+	configuration.Output.MaxIssues = maxIssues
+}
+
 func OverrideIncludeTgCache(includeTgCache bool) {
+	markSetByFlag("output.include_terragrunt_cache")
 	configuration.Output.IncludeTerragruntCache = NullableBool{
 		HasValue: true,
 		IsTrue:   includeTgCache,
@@ -1,27 +1,48 @@
 package config
 
 import (
-	_ "embed"
+	"bytes"
+	"embed"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"dario.cat/mergo"
 	"github.com/kelseyhightower/envconfig"
 	"gopkg.in/yaml.v3"
 )
 
+const (
+	gitHubActionsEnv    = "GITHUB_ACTIONS"
+	gitHubActionsFormat = "github"
+
+	presetDir       = "presets"
+	presetExtension = ".yml"
+)
+
 var (
 	// ship the default config with the app
 	//
 	//go:embed .tfcoach.default.yml
 	yamlDefaultData []byte
 
+	// presets that configuration files can extend by name
+	//
+	//go:embed presets/*.yml
+	presetFiles embed.FS
+
 	configuration config
 
+	// lenient makes loading ignore unknown keys, unknown rules and configuration files that cannot be loaded
+	lenient bool
+
 	homeDirConfigRelativePaths = []string{
 		filepath.Join(".config", "tfcoach"),
 		".tfcoach",
@@ -36,18 +57,34 @@ var (
 )
 
 func GetConfigByRuleID(ruleID string) RuleConfiguration {
-	ruleConfiguration, ok := configuration.Rules[ruleID]
+	return configuration.Rules.ByID(ruleID)
+}
 
-	if ok {
-		return ruleConfiguration
-	}
-	return RuleConfiguration{Enabled: true}
+// GetRuleConfigurations returns the configuration of all configured rules.
+func GetRuleConfigurations() RuleConfigurations {
+	return maps.Clone(configuration.Rules)
+}
+
+// StandardConfigFileNames returns the names of configuration files, in the order they are looked up in a directory.
+func StandardConfigFileNames() []string {
+	return slices.Clone(standardConfigFileNames)
 }
 
 func GetOutputConfiguration() OutputConfiguration {
 	return configuration.Output
 }
 
+// GetEffectiveConfiguration returns the merged configuration of all sources, e.g. to document it in reports.
+func GetEffectiveConfiguration() any {
+	return configuration
+}
+
+// SetLenient switches between strict loading, which rejects unknown keys, unknown rules and configuration files that
+// cannot be loaded, and lenient loading, which ignores them with a warning.
+func SetLenient(enabled bool) {
+	lenient = enabled
+}
+
 func LoadDefaultConfig() error {
 	var configData config
 	err := loadConfigFromYaml(yamlDefaultData, &configData)
@@ -61,6 +98,8 @@ func LoadDefaultConfig() error {
 	}
 
 	configuration = configData
+	layers = []Layer{{Source: SourceDefault, data: configData}}
+	flagPaths = nil
 	return nil
 }
 
@@ -71,24 +110,35 @@ func LoadConfig(navigator Navigator) error {
 	if err != nil {
 		return err
 	}
+	// inside GitHub Actions, default to workflow annotations unless configured otherwise
+	if os.Getenv(gitHubActionsEnv) == "true" {
+		configData.Output.Format = gitHubActionsFormat
+	}
+	loadedLayers := []Layer{{Source: SourceDefault, data: configData}}
 
 	// 2. config from home dir
-	var homeConfigData config
-	homeConfigData, err = loadConfigFromHomeDir(navigator)
-	if err != nil {
-		slog.Warn("error loading home config", "err", err)
-	} else if mergeErr := mergeInto(&configData, homeConfigData); mergeErr != nil {
+	homeLayer := Layer{Source: SourceHome}
+	homeLayer.data, homeLayer.Path, homeLayer.Err = loadConfigFromHomeDir(navigator)
+	if homeLayer.Err != nil && homeLayer.Path != "" && !lenient {
+		return strictLoadError(homeLayer.Err)
+	} else if homeLayer.Err != nil {
+		slog.Warn("error loading home config", "err", homeLayer.Err)
+	} else if mergeErr := mergeInto(&configData, homeLayer.data); mergeErr != nil {
 		return mergeErr
 	}
+	loadedLayers = append(loadedLayers, homeLayer)
 
 	// 3. config from current dir
-	var customConfigData config
-	customConfigData, err = loadConfigFromLocalFile(navigator)
-	if err != nil {
-		slog.Warn("error loading custom config", "err", err)
-	} else if mergeErr := mergeInto(&configData, customConfigData); mergeErr != nil {
+	localLayer := Layer{Source: SourceLocal}
+	localLayer.data, localLayer.Path, localLayer.Err = loadConfigFromLocalFile(navigator)
+	if localLayer.Err != nil && localLayer.Path != "" && !lenient {
+		return strictLoadError(localLayer.Err)
+	} else if localLayer.Err != nil {
+		slog.Warn("error loading custom config", "err", localLayer.Err)
+	} else if mergeErr := mergeInto(&configData, localLayer.data); mergeErr != nil {
 		return mergeErr
 	}
+	loadedLayers = append(loadedLayers, localLayer)
 
 	// 4. config from env
 	var envData config
@@ -100,6 +150,7 @@ func LoadConfig(navigator Navigator) error {
 	if mergeErr != nil {
 		return mergeErr
 	}
+	loadedLayers = append(loadedLayers, Layer{Source: SourceEnv, data: envData})
 
 	// 5. validate
 	validationErr := configData.Validate()
@@ -108,38 +159,138 @@ func LoadConfig(navigator Navigator) error {
 	}
 
 	configuration = configData
+	layers = loadedLayers
+	flagPaths = nil
 	return nil
 }
 
-func loadConfigFromHomeDir(navigator Navigator) (config, error) {
+func strictLoadError(err error) error {
+	return fmt.Errorf("%w\nuse --lenient-config to ignore unknown keys and rules", err)
+}
+
+func loadConfigFromHomeDir(navigator Navigator) (config, string, error) {
 	homeConfigPath, found := getHomeConfigPath(navigator)
 	if !found {
-		return config{}, errors.New("no config found in home directory")
+		return config{}, "", errors.New("no config found in home directory")
 	}
 
 	homeConfigData, err := loadCustomConfigFromFile(homeConfigPath)
 	if err != nil {
-		return config{}, fmt.Errorf("could load config from home directory: %v", err)
+		return config{}, homeConfigPath, fmt.Errorf("could not load config %s from home directory: %w", homeConfigPath, err)
 	}
 
-	return homeConfigData, nil
+	return homeConfigData, homeConfigPath, nil
 }
 
-func loadConfigFromLocalFile(navigator Navigator) (config, error) {
+func loadConfigFromLocalFile(navigator Navigator) (config, string, error) {
 	customConfigPath, found := getCustomConfigPath(navigator)
 	if !found {
-		return config{}, errors.New("no config found in local directory")
+		return config{}, "", errors.New("no config found in local directory")
 	}
 
 	customConfigData, err := loadCustomConfigFromFile(customConfigPath)
 	if err != nil {
-		return config{}, fmt.Errorf("could load config from local directory: %v", err)
+		return config{}, customConfigPath, fmt.Errorf("could not load config %s: %w", customConfigPath, err)
 	}
 
-	return customConfigData, nil
+	return customConfigData, customConfigPath, nil
+}
+
+// FindLocalConfigFile returns the local configuration file, which is the custom config path or the first standard
+// configuration file in the current directory.
+func FindLocalConfigFile(customConfigPath string) (string, bool) {
+	return getCustomConfigPath(&DefaultNavigator{CustomConfigPath: customConfigPath})
+}
+
+// PresetNames returns the names of the presets that configuration files can extend.
+func PresetNames() []string {
+	entries, _ := presetFiles.ReadDir(presetDir)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), presetExtension))
+	}
+	return names
 }
 
+// loadCustomConfigFromFile loads a configuration file merged over the configurations it extends.
 func loadCustomConfigFromFile(configPath string) (config, error) {
+	return loadExtendingConfig(configPath, nil)
+}
+
+// loadExtendingConfig loads a configuration file merged over the configurations it extends. chain holds the files that
+// extend it, to detect cycles.
+func loadExtendingConfig(configPath string, chain []string) (config, error) {
+	absPath, err := filepath.Abs(configPath)
+	if err != nil {
+		return config{}, err
+	}
+	if slices.Contains(chain, absPath) {
+		return config{}, fmt.Errorf("cyclic extends: %s", strings.Join(append(chain, absPath), " -> "))
+	}
+
+	configData, err := readConfigFile(configPath)
+	if err != nil {
+		return config{}, err
+	}
+	return resolveExtends(configData, filepath.Dir(absPath), append(chain, absPath))
+}
+
+// resolveExtends merges configData over the presets and files it extends, in the order they are listed. Relative paths
+// are relative to baseDir. The overrides of all of them are kept, the ones of configData are applied last.
+func resolveExtends(configData config, baseDir string, chain []string) (config, error) {
+	if len(configData.Extends) == 0 {
+		return configData, nil
+	}
+
+	var merged config
+	for _, ref := range configData.Extends {
+		var extended config
+		var err error
+		if isPresetRef(ref) {
+			extended, err = loadPreset(ref)
+		} else {
+			if !filepath.IsAbs(ref) {
+				ref = filepath.Join(baseDir, ref)
+			}
+			extended, err = loadExtendingConfig(ref, chain)
+		}
+		if err != nil {
+			return config{}, fmt.Errorf("could not extend %s: %w", ref, err)
+		}
+		overrides := slices.Concat(merged.Overrides, extended.Overrides)
+		if mergeErr := mergeInto(&merged, extended); mergeErr != nil {
+			return config{}, mergeErr
+		}
+		merged.Overrides = overrides
+	}
+
+	overrides := slices.Concat(merged.Overrides, configData.Overrides)
+	configData.Extends = nil
+	if mergeErr := mergeInto(&merged, configData); mergeErr != nil {
+		return config{}, mergeErr
+	}
+	merged.Overrides = overrides
+	return merged, nil
+}
+
+// isPresetRef reports whether an entry of extends names a preset rather than a file, which is the case for plain
+// names like "recommended".
+func isPresetRef(ref string) bool {
+	return !strings.ContainsAny(ref, `/\`) && filepath.Ext(ref) == ""
+}
+
+// loadPreset loads a preset, which does not extend others.
+func loadPreset(name string) (config, error) {
+	presetData, err := presetFiles.ReadFile(presetDir + "/" + name + presetExtension)
+	if err != nil {
+		return config{}, fmt.Errorf("unknown preset %q (available: %s)", name, strings.Join(PresetNames(), ", "))
+	}
+	var configData config
+	err = loadConfigFromYaml(presetData, &configData)
+	return configData, err
+}
+
+func readConfigFile(configPath string) (config, error) {
 	var appData config
 	extension := filepath.Ext(configPath)
 	configData, err := os.ReadFile(configPath)
@@ -163,11 +314,28 @@ func loadConfigFromEnv(mapData *config) error {
 }
 
 func loadConfigFromYaml(data []byte, mapData *config) error {
-	return yaml.Unmarshal(data, mapData)
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(!lenient)
+	err := decoder.Decode(mapData)
+	if errors.Is(err, io.EOF) {
+		// an empty file
+		return nil
+	}
+	if err != nil {
+		return withKeySuggestions(err)
+	}
+	return nil
 }
 
 func loadConfigFromJSON(data []byte, mapData *config) error {
-	return json.Unmarshal(data, mapData)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if !lenient {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(mapData); err != nil {
+		return withKeySuggestions(err)
+	}
+	return nil
 }
 
 func getHomeConfigPath(navigator Navigator) (string, bool) {
@@ -220,6 +388,15 @@ func getFirstMatchingPath(baseDir string, fileNames []string) (string, bool) {
 	return "", false
 }
 
+// mergeInto merges the values of updated that are set over target. Rules are merged field by field, so that e.g. a
+// rule entry that only sets the severity keeps the rule enabled.
 func mergeInto(target *config, updated config) error {
-	return mergo.Merge(target, updated, mergo.WithOverride, mergo.WithTransformers(NullableBoolTransformer{}))
+	rules := target.Rules.mergedWith(updated.Rules)
+	updated.Rules = nil
+	err := mergo.Merge(target, updated, mergo.WithOverride, mergo.WithTransformers(NullableBoolTransformer{}))
+	if err != nil {
+		return err
+	}
+	target.Rules = rules
+	return nil
 }
@@ -6,7 +6,10 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/Marcel2603/tfcoach/internal/types"
 )
 
 type navigatorMock struct {
@@ -45,9 +48,23 @@ output:
   color: true
   emojis: true
   include_terragrunt_cache: false
+  fail_on: low
 `)
 }
 
+// registerRules makes rules without options known for the duration of a test.
+func registerRules(t *testing.T, ruleIDs ...string) {
+	t.Helper()
+	for _, ruleID := range ruleIDs {
+		RegisterRuleOptions(ruleID, nil)
+	}
+	t.Cleanup(func() {
+		for _, ruleID := range ruleIDs {
+			delete(ruleOptions, ruleID)
+		}
+	})
+}
+
 func TestLoadDefaultConfig(t *testing.T) {
 	err := LoadDefaultConfig()
 
@@ -108,12 +125,13 @@ output:
 	contentHomeJSON := []byte(`{"rules": {"RULE_1": {"enabled": false}}, "output": {"format": "compact", "color": false}}`)
 
 	want := config{
-		Rules: map[string]RuleConfiguration{"RULE_1": {Enabled: false}},
+		Rules: map[string]RuleConfiguration{"RULE_1": {Enabled: NullableBool{HasValue: true}}},
 		Output: OutputConfiguration{
 			Format:                 "compact",
 			Color:                  NullableBool{HasValue: true, IsTrue: false},
 			Emojis:                 NullableBool{HasValue: true, IsTrue: true},
 			IncludeTerragruntCache: NullableBool{HasValue: true, IsTrue: false},
+			FailOn:                 "low",
 		},
 	}
 
@@ -197,12 +215,13 @@ output:
 	contentJSON := []byte(`{"rules": {"RULE_1": {"enabled": false}}, "output": {"format": "compact", "color": false, "emojis": false, "include_terragrunt_cache": true}}`)
 
 	want := config{
-		Rules: map[string]RuleConfiguration{"RULE_1": {Enabled: false}},
+		Rules: map[string]RuleConfiguration{"RULE_1": {Enabled: NullableBool{HasValue: true}}},
 		Output: OutputConfiguration{
 			Format:                 "compact",
 			Color:                  NullableBool{HasValue: true, IsTrue: false},
 			Emojis:                 NullableBool{HasValue: true, IsTrue: false},
 			IncludeTerragruntCache: NullableBool{HasValue: true, IsTrue: true},
+			FailOn:                 "low",
 		},
 	}
 
@@ -267,12 +286,13 @@ output:
 	contentCustomJSON := []byte(`{"rules": {"RULE_2": {"enabled": false}}, "output": {"format": "pretty", "emojis": true, "include_terragrunt_cache": false}}`)
 
 	want := config{
-		Rules: map[string]RuleConfiguration{"RULE_1": {Enabled: false}, "RULE_2": {Enabled: false}},
+		Rules: map[string]RuleConfiguration{"RULE_1": {Enabled: NullableBool{HasValue: true}}, "RULE_2": {Enabled: NullableBool{HasValue: true}}},
 		Output: OutputConfiguration{
 			Format:                 "pretty",
 			Color:                  NullableBool{HasValue: true, IsTrue: false},
 			Emojis:                 NullableBool{HasValue: true, IsTrue: true},
 			IncludeTerragruntCache: NullableBool{HasValue: true, IsTrue: false},
+			FailOn:                 "low",
 		},
 	}
 
@@ -345,12 +365,13 @@ output:
 	contentCustomJSON := []byte(`{"rules": {"RULE_2": {"enabled": false}}, "output": {"format": "pretty", "color": false}}`)
 
 	want := config{
-		Rules: map[string]RuleConfiguration{"RULE_2": {Enabled: false}},
+		Rules: map[string]RuleConfiguration{"RULE_2": {Enabled: NullableBool{HasValue: true}}},
 		Output: OutputConfiguration{
 			Format:                 "pretty",
 			Color:                  NullableBool{HasValue: true, IsTrue: false},
 			Emojis:                 NullableBool{HasValue: true, IsTrue: true},
 			IncludeTerragruntCache: NullableBool{HasValue: true, IsTrue: false},
+			FailOn:                 "low",
 		},
 	}
 
@@ -413,12 +434,13 @@ output:
 	contentJSON := []byte(`{"rules": {"RULE_1": {"enabled": false}}, "output": {"format": "pretty", "color": false}}`)
 
 	want := config{
-		Rules: map[string]RuleConfiguration{"RULE_1": {Enabled: false}},
+		Rules: map[string]RuleConfiguration{"RULE_1": {Enabled: NullableBool{HasValue: true}}},
 		Output: OutputConfiguration{
 			Format:                 "pretty",
 			Color:                  NullableBool{HasValue: true, IsTrue: false},
 			Emojis:                 NullableBool{HasValue: true, IsTrue: true},
 			IncludeTerragruntCache: NullableBool{HasValue: true, IsTrue: false},
+			FailOn:                 "low",
 		},
 	}
 
@@ -503,6 +525,11 @@ func TestLoadConfig_InvalidOverride(t *testing.T) {
 }
 
 func TestLoadConfig_InvalidCustomFileIsIgnored(t *testing.T) {
+	t.Setenv("GITHUB_ACTIONS", "")
+	SetLenient(true)
+	t.Cleanup(func() {
+		SetLenient(false)
+	})
 	contentYAML := []byte(`rules: {::: {"enabled": false}}`)
 	contentJSON := []byte(`{"rules": {4}}`)
 
@@ -513,6 +540,7 @@ func TestLoadConfig_InvalidCustomFileIsIgnored(t *testing.T) {
 			Color:                  NullableBool{HasValue: true, IsTrue: true},
 			Emojis:                 NullableBool{HasValue: true, IsTrue: true},
 			IncludeTerragruntCache: NullableBool{HasValue: true, IsTrue: false},
+			FailOn:                 "low",
 		},
 	}
 
@@ -555,8 +583,298 @@ func TestLoadConfig_InvalidCustomFileIsIgnored(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_GitHubActionsDefaultFormat(t *testing.T) {
+	t.Setenv("GITHUB_ACTIONS", "true")
+
+	t.Run("no config file", func(t *testing.T) {
+		_ = os.Chdir(t.TempDir())
+		err := LoadConfig(&navigatorMock{homeDir: t.TempDir()})
+		if err != nil {
+			t.Errorf("LoadConfig() error = %v", err)
+		}
+		if configuration.Output.Format != "github" {
+			t.Errorf("Expected format %q, got %q", "github", configuration.Output.Format)
+		}
+	})
+
+	t.Run("format set in config file", func(t *testing.T) {
+		dir := t.TempDir()
+		_ = os.Chdir(dir)
+		_ = os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), []byte("output:\n  format: sarif\n"), 0644)
+		err := LoadConfig(&navigatorMock{homeDir: t.TempDir()})
+		if err != nil {
+			t.Errorf("LoadConfig() error = %v", err)
+		}
+		if configuration.Output.Format != "sarif" {
+			t.Errorf("Expected format %q, got %q", "sarif", configuration.Output.Format)
+		}
+	})
+}
+
+func TestLoadConfig_OutputTargets(t *testing.T) {
+	content := []byte(`output:
+  targets:
+    - format: educational
+    - format: json
+      path: tfcoach.json
+      emojis: false
+`)
+	want := []OutputTarget{
+		{Format: "educational"},
+		{Format: "json", Path: "tfcoach.json", Emojis: NullableBool{HasValue: true, IsTrue: false}},
+	}
+
+	dir := t.TempDir()
+	_ = os.Chdir(dir)
+	_ = os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), content, 0644)
+	err := LoadConfig(&navigatorMock{homeDir: t.TempDir()})
+	if err != nil {
+		t.Errorf("LoadConfig() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(configuration.Output.Targets, want) {
+		t.Errorf("Expected %+v, got %+v", want, configuration.Output.Targets)
+	}
+}
+
+func TestLoadConfig_InvalidOutputTarget(t *testing.T) {
+	content := []byte(`output:
+  targets:
+    - format: abcd
+`)
+
+	dir := t.TempDir()
+	_ = os.Chdir(dir)
+	_ = os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), content, 0644)
+	err := LoadConfig(&navigatorMock{homeDir: t.TempDir()})
+	if err == nil {
+		t.Errorf("Expected error, got none")
+	}
+}
+
+func TestLoadConfig_TemplateFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "format", content: "output:\n  format: template\n", wantErr: `invalid format: "template" requires a template`},
+		{name: "target", content: "output:\n  targets:\n    - format: json\n    - format: template\n", wantErr: `invalid target 1: format "template" requires a template`},
+		{name: "format with template", content: "output:\n  format: template\n  template: report.tmpl\n"},
+		{name: "target with template", content: "output:\n  targets:\n    - format: template\n      template: report.tmpl\n"},
+		{name: "target with inherited template", content: "output:\n  template: report.tmpl\n  targets:\n    - format: template\n"},
+		{name: "format replaced by targets", content: "output:\n  format: template\n  targets:\n    - format: json\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			_ = os.Chdir(dir)
+			_ = os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), []byte(tt.content), 0644)
+			err := LoadConfig(&navigatorMock{homeDir: t.TempDir()})
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("LoadConfig() error = %v, want none", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("LoadConfig() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_InvalidFailPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "fail_on", content: "output:\n  fail_on: critical\n"},
+		{name: "max_issues", content: "output:\n  max_issues: -1\n"},
+		{name: "rule severity", content: "rules:\n  core.file_naming:\n    severity: critical\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			_ = os.Chdir(dir)
+			_ = os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), []byte(tt.content), 0644)
+			err := LoadConfig(&navigatorMock{homeDir: t.TempDir()})
+			if err == nil {
+				t.Errorf("Expected error, got none")
+			}
+		})
+	}
+}
+
+func TestLoadConfig_RuleSpec(t *testing.T) {
+	RegisterRuleOptions("test.configurable", []types.RuleOption{
+		{Name: "max_length", Type: types.RuleOptionNumber, Default: 64},
+	})
+	t.Cleanup(func() {
+		delete(ruleOptions, "test.configurable")
+	})
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{name: "valid", content: "rules:\n  test.configurable:\n    spec:\n      max_length: 32\n"},
+		{name: "unknown option", content: "rules:\n  test.configurable:\n    spec:\n      min_length: 3\n", wantErr: true},
+		{name: "wrong type", content: "rules:\n  test.configurable:\n    spec:\n      max_length: long\n", wantErr: true},
+		{name: "unknown rule", content: "rules:\n  test.other:\n    spec:\n      foo: bar\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			_ = os.Chdir(dir)
+			_ = os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), []byte(tt.content), 0644)
+			err := LoadConfig(&navigatorMock{homeDir: t.TempDir()})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_RuleOverrides(t *testing.T) {
+	RegisterRuleOptions("test.configurable", []types.RuleOption{
+		{Name: "max_length", Type: types.RuleOptionNumber, Default: 64},
+	})
+	t.Cleanup(func() {
+		delete(ruleOptions, "test.configurable")
+	})
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{name: "valid", content: "overrides:\n  - files: [examples/**]\n    rules:\n      test.configurable:\n        spec:\n          max_length: 80\n"},
+		{name: "without files", content: "overrides:\n  - rules:\n      test.configurable:\n        enabled: false\n", wantErr: true},
+		{name: "wrong type", content: "overrides:\n  - files: [examples/**]\n    rules:\n      test.configurable:\n        spec:\n          max_length: long\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			_ = os.Chdir(dir)
+			_ = os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), []byte(tt.content), 0644)
+			err := LoadConfig(&navigatorMock{homeDir: t.TempDir()})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_Extends(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.Chdir(dir)
+	_ = os.MkdirAll(filepath.Join(dir, "shared"), 0755)
+	_ = os.WriteFile(filepath.Join(dir, "shared", "base.yml"), []byte(`extends: [parent.json]
+rules:
+  RULE_1:
+    enabled: false
+  core.use_cloud_backend:
+    enabled: true
+overrides:
+  - files: [examples/**]
+    rules:
+      RULE_1:
+        enabled: true
+output:
+  format: compact
+`), 0644)
+	_ = os.WriteFile(filepath.Join(dir, "shared", "parent.json"), []byte(`{"rules": {"RULE_2": {"enabled": false}}, "output": {"fail_on": "medium"}}`), 0644)
+	_ = os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), []byte(`extends: [recommended, ./shared/base.yml]
+rules:
+  RULE_2:
+    enabled: true
+overrides:
+  - files: [test/**]
+    rules:
+      RULE_2:
+        enabled: false
+`), 0644)
+
+	if err := LoadConfig(&navigatorMock{homeDir: t.TempDir()}); err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	wantRules := RuleConfigurations{
+		"RULE_1":                 {Enabled: NullableBool{HasValue: true}},
+		"RULE_2":                 {Enabled: NullableBool{HasValue: true, IsTrue: true}},
+		"core.use_cloud_backend": {Enabled: NullableBool{HasValue: true, IsTrue: true}},
+	}
+	if !reflect.DeepEqual(configuration.Rules, wantRules) {
+		t.Errorf("Rules = %+v, want %+v", configuration.Rules, wantRules)
+	}
+	if len(configuration.Overrides) != 2 || configuration.Overrides[0].Files[0] != "examples/**" {
+		t.Errorf("Overrides = %+v, want the extended ones first", configuration.Overrides)
+	}
+	if configuration.Output.Format != "compact" || configuration.Output.FailOn != "medium" {
+		t.Errorf("Output = %+v, want the output of the extended files", configuration.Output)
+	}
+	if len(configuration.Extends) != 0 {
+		t.Errorf("Extends = %v, want it resolved", configuration.Extends)
+	}
+}
+
+func TestLoadCustomConfigFromFile_InvalidExtends(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name:    "unknown preset",
+			files:   map[string]string{".tfcoach.yml": "extends: [relaxed]"},
+			wantErr: `unknown preset "relaxed" (available: minimal, recommended, strict)`,
+		},
+		{
+			name:    "missing file",
+			files:   map[string]string{".tfcoach.yml": "extends: [./missing.yml]"},
+			wantErr: "could not extend",
+		},
+		{
+			name: "cycle",
+			files: map[string]string{
+				".tfcoach.yml": "extends: [a.yml]",
+				"a.yml":        "extends: [./b.yml]",
+				"b.yml":        "extends: [./a.yml]",
+			},
+			wantErr: "cyclic extends",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				_ = os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+			}
+			_, err := loadCustomConfigFromFile(filepath.Join(dir, ".tfcoach.yml"))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("loadCustomConfigFromFile() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPresets(t *testing.T) {
+	for _, name := range PresetNames() {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			_ = os.Chdir(dir)
+			_ = os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), []byte("extends: ["+name+"]"), 0644)
+			if err := LoadConfig(&navigatorMock{homeDir: t.TempDir()}); err != nil {
+				t.Errorf("LoadConfig() error = %v", err)
+			}
+			if len(configuration.Rules) == 0 {
+				t.Errorf("preset %s configures no rules", name)
+			}
+		})
+	}
+}
+
 func TestGetConfigByRuleId(t *testing.T) {
-	content := []byte(`{"rules": {"RULE_1": {"enabled": false, "spec": {"foo":"bar"}}}, "output": {"format": "compact", "color": false, "emojis": true, "ignore_terragrunt_cache": true}}`)
+	content := []byte(`{"rules": {"RULE_1": {"enabled": false, "spec": {"foo":"bar"}}}, "output": {"format": "compact", "color": false, "emojis": true, "include_terragrunt_cache": true}}`)
 
 	tests := []struct {
 		ruleID   string
@@ -564,11 +882,11 @@ func TestGetConfigByRuleId(t *testing.T) {
 	}{
 		{
 			ruleID:   "not_found",
-			expected: RuleConfiguration{Enabled: true},
+			expected: RuleConfiguration{Enabled: NullableBool{HasValue: true, IsTrue: true}},
 		},
 		{
 			ruleID:   "RULE_1",
-			expected: RuleConfiguration{Enabled: false, Spec: map[string]string{"foo": "bar"}},
+			expected: RuleConfiguration{Enabled: NullableBool{HasValue: true}, Spec: map[string]any{"foo": "bar"}},
 		},
 	}
 	for _, tt := range tests {
@@ -582,7 +900,7 @@ func TestGetConfigByRuleId(t *testing.T) {
 			}
 
 			ruleConfig := GetConfigByRuleID(tt.ruleID)
-			if ruleConfig.Enabled != tt.expected.Enabled {
+			if ruleConfig.IsEnabled() != tt.expected.IsEnabled() {
 				t.Errorf("Expected %+v, got %+v", tt.expected, ruleConfig)
 			}
 			if !reflect.DeepEqual(ruleConfig.Spec, tt.expected.Spec) {
@@ -606,6 +924,7 @@ func TestGetOutputConfiguration(t *testing.T) {
 		Color:                  NullableBool{HasValue: true, IsTrue: false},
 		Emojis:                 NullableBool{HasValue: true, IsTrue: true},
 		IncludeTerragruntCache: NullableBool{HasValue: true, IsTrue: false},
+		FailOn:                 "low",
 	}
 
 	tests := []struct {
@@ -641,9 +960,144 @@ func TestGetOutputConfiguration(t *testing.T) {
 
 			var got OutputConfiguration
 			got = GetOutputConfiguration()
-			if got != want {
+			if !reflect.DeepEqual(got, want) {
 				t.Errorf("Expected %+v, got %+v", want, got)
 			}
 		})
 	}
 }
+
+func TestLoadConfig_Strict(t *testing.T) {
+	registerRules(t, "core.file_naming")
+
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		wantErr  string
+	}{
+		{name: "unknown key", filename: ".tfcoach.yml", content: "rules:\n  core.file_naming:\n    enabeld: false\n", wantErr: `field enabeld not found in type config.RuleConfiguration (did you mean "enabled"?)`},
+		{name: "unknown key in JSON", filename: ".tfcoach.json", content: `{"output": {"fromat": "json"}}`, wantErr: `unknown field "fromat" (did you mean "format"?)`},
+		{name: "unknown rule", filename: ".tfcoach.yml", content: "rules:\n  core.file_namng:\n    enabled: false\n", wantErr: `unknown rule "core.file_namng" (did you mean "core.file_naming"?)`},
+		{name: "unknown rule in override", filename: ".tfcoach.yml", content: "overrides:\n  - files: [examples/**]\n    rules:\n      file_naming:\n        enabled: false\n", wantErr: `invalid override 1: unknown rule "file_naming"`},
+		{name: "invalid file", filename: ".tfcoach.yml", content: `rules: {::: {"enabled": false}}`, wantErr: "could not load config"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			_ = os.Chdir(dir)
+			_ = os.WriteFile(filepath.Join(dir, tt.filename), []byte(tt.content), 0644)
+			err := LoadConfig(&navigatorMock{homeDir: t.TempDir()})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadConfig() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_Lenient(t *testing.T) {
+	registerRules(t, "core.file_naming")
+	SetLenient(true)
+	t.Cleanup(func() {
+		SetLenient(false)
+	})
+
+	dir := t.TempDir()
+	_ = os.Chdir(dir)
+	content := "rules:\n  core.file_naming:\n    enabled: false\n    enabeld: true\n  core.file_namng:\n    enabled: false\n"
+	_ = os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), []byte(content), 0644)
+	if err := LoadConfig(&navigatorMock{homeDir: t.TempDir()}); err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if GetConfigByRuleID("core.file_naming").IsEnabled() {
+		t.Errorf("expected the known keys to be loaded")
+	}
+}
+
+func TestDidYouMean(t *testing.T) {
+	candidates := []string{"core.file_naming", "core.naming_convention", "enabled"}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "enabeld", want: ` (did you mean "enabled"?)`},
+		{name: "core.file_namig", want: ` (did you mean "core.file_naming"?)`},
+		{name: "core.naming", want: ""},
+		{name: "severity", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := didYouMean(tt.name, candidates); got != tt.want {
+				t.Errorf("didYouMean() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_SeverityOnlyRuleEntry(t *testing.T) {
+	registerRules(t, "RULE_1", "RULE_2")
+	homeDir := t.TempDir()
+	homeConfigDir := filepath.Join(homeDir, ".tfcoach")
+	_ = os.MkdirAll(homeConfigDir, 0777)
+	_ = os.WriteFile(filepath.Join(homeConfigDir, ".tfcoach.yml"), []byte("rules:\n  RULE_2:\n    enabled: false\n"), 0644)
+	dir := t.TempDir()
+	_ = os.Chdir(dir)
+	_ = os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), []byte("rules:\n  RULE_1:\n    severity: low\n  RULE_2:\n    severity: high\n"), 0644)
+
+	if err := LoadConfig(&navigatorMock{homeDir: homeDir}); err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if got := GetConfigByRuleID("RULE_1"); !got.IsEnabled() || got.Severity != "low" {
+		t.Errorf("expected RULE_1 to stay enabled with severity low, got %+v", got)
+	}
+	if got := GetConfigByRuleID("RULE_2"); got.IsEnabled() || got.Severity != "high" {
+		t.Errorf("expected RULE_2 to stay disabled by the home config with severity high, got %+v", got)
+	}
+}
+
+func TestLoadConfig_SpecOnlyRuleEntry(t *testing.T) {
+	RegisterRuleOptions("test.configurable", []types.RuleOption{
+		{Name: "max_length", Type: types.RuleOptionNumber, Default: 64},
+		{Name: "preset", Type: types.RuleOptionString, Default: "snake_case"},
+	})
+	t.Cleanup(func() {
+		delete(ruleOptions, "test.configurable")
+	})
+	homeDir := t.TempDir()
+	homeConfigDir := filepath.Join(homeDir, ".tfcoach")
+	_ = os.MkdirAll(homeConfigDir, 0777)
+	_ = os.WriteFile(filepath.Join(homeConfigDir, ".tfcoach.yml"), []byte("rules:\n  test.configurable:\n    spec:\n      preset: camelCase\n      max_length: 32\n"), 0644)
+	dir := t.TempDir()
+	_ = os.Chdir(dir)
+	_ = os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), []byte("rules:\n  test.configurable:\n    spec:\n      max_length: 80\n"), 0644)
+
+	if err := LoadConfig(&navigatorMock{homeDir: homeDir}); err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	got := GetConfigByRuleID("test.configurable")
+	if !got.IsEnabled() {
+		t.Errorf("expected the rule to stay enabled, got %+v", got)
+	}
+	if want := map[string]any{"preset": "camelCase", "max_length": 80}; !reflect.DeepEqual(got.Spec, want) {
+		t.Errorf("expected the spec to be merged option by option, got %v, want %v", got.Spec, want)
+	}
+}
+
+func TestLoadConfig_ExtendsPresetWithPartialRuleEntry(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.Chdir(dir)
+	content := "extends: [strict]\nrules:\n  core.naming_convention:\n    severity: high\n"
+	_ = os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), []byte(content), 0644)
+
+	if err := LoadConfig(&navigatorMock{homeDir: t.TempDir()}); err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	want := RuleConfiguration{
+		Enabled:  NullableBool{HasValue: true, IsTrue: true},
+		Severity: "high",
+		Spec:     map[string]any{"max_length": 64},
+	}
+	if got := GetConfigByRuleID("core.naming_convention"); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the entry to be merged into the one of the preset, got %+v, want %+v", got, want)
+	}
+}
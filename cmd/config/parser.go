@@ -11,9 +11,11 @@ import (
 
 var (
 	formatFlag     string
+	templateFlag   string
 	noColorFlag    bool
 	noEmojisFlag   bool
 	configPathFlag string
+	lenientFlag    bool
 
 	defaultOutputConfig OutputConfiguration
 	finalOutputConfig   OutputConfiguration
@@ -25,14 +27,19 @@ func AddStandardFlags(cmd *cobra.Command) {
 	formatUsageHelp := fmt.Sprintf("Output format. Supported: %s", strings.Join(SupportedFormats(), "|"))
 	cmd.Flags().StringVarP(&formatFlag, "format", "f", defaultOutputConfig.Format, formatUsageHelp)
 
+	cmd.Flags().StringVar(&templateFlag, "template", defaultOutputConfig.Template, "Go text/template file rendered by the \"template\" format")
+
 	cmd.Flags().BoolVar(&noColorFlag, "no-color", !defaultOutputConfig.Color.IsTrue, "Disable color output")
 
 	cmd.Flags().BoolVar(&noEmojisFlag, "no-emojis", !defaultOutputConfig.Emojis.IsTrue, "Prevent emojis in output")
 
 	cmd.Flags().StringVarP(&configPathFlag, "config", "c", "", "Custom config file path (default current directory)")
+
+	cmd.Flags().BoolVar(&lenientFlag, "lenient-config", false, "Ignore unknown keys and rules in config files instead of failing")
 }
 
 func ParseStandardFlags(cmd *cobra.Command) error {
+	SetLenient(lenientFlag)
 	err := LoadConfig(&DefaultNavigator{CustomConfigPath: configPathFlag})
 	if err != nil {
 		return err
@@ -41,6 +48,9 @@ func ParseStandardFlags(cmd *cobra.Command) error {
 	if cmd.Flags().Changed("format") {
 		OverrideFormat(formatFlag)
 	}
+	if cmd.Flags().Changed("template") {
+		OverrideTemplate(templateFlag)
+	}
 	if cmd.Flags().Changed("no-color") {
 		OverrideColor(!noColorFlag)
 	}
@@ -51,8 +61,11 @@ func ParseStandardFlags(cmd *cobra.Command) error {
 	finalOutputConfig = GetOutputConfiguration()
 	color.NoColor = !finalOutputConfig.Color.IsTrue
 
-	if slices.Contains(SupportedFormats(), finalOutputConfig.Format) {
-		return nil
+	if !slices.Contains(SupportedFormats(), finalOutputConfig.Format) {
+		return fmt.Errorf("invalid --format: %s (want %s)", finalOutputConfig.Format, strings.Join(SupportedFormats(), "|"))
+	}
+	if finalOutputConfig.Format == templateFormat && finalOutputConfig.Template == "" {
+		return fmt.Errorf("--format %s requires --template", templateFormat)
 	}
-	return fmt.Errorf("invalid --format: %s (want %s)", finalOutputConfig.Format, strings.Join(SupportedFormats(), "|"))
+	return nil
 }
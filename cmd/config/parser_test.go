@@ -1,7 +1,11 @@
 package config_test
 
 import (
+	"encoding/json"
 	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/Marcel2603/tfcoach/cmd/config"
@@ -12,7 +16,7 @@ func TestAddStandardFlags(t *testing.T) {
 	cmd := &cobra.Command{}
 	config.AddStandardFlags(cmd)
 
-	expectedFlags := []string{"format", "no-color", "no-emojis", "config"}
+	expectedFlags := []string{"format", "template", "no-color", "no-emojis", "config"}
 	for _, flagName := range expectedFlags {
 		flag := cmd.Flags().Lookup(flagName)
 		if flag == nil {
@@ -49,6 +53,30 @@ func TestParseStandardFlags_ShouldOverrideVariables(t *testing.T) {
 	}
 }
 
+func TestParseStandardFlags_FormatReplacesConfiguredTargets(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	t.Chdir(dir)
+	content := "output:\n  targets:\n    - format: compact\n    - format: sarif\n      path: tfcoach.sarif\n"
+	if err := os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), []byte(content), 0o644); err != nil {
+		t.Fatalf("setup error: %v", err)
+	}
+	cmd := &cobra.Command{}
+	config.AddStandardFlags(cmd)
+	if err := cmd.Flags().Set("format", "json"); err != nil {
+		t.Fatalf("setup error: %v", err)
+	}
+
+	if err := config.ParseStandardFlags(cmd); err != nil {
+		t.Fatalf("ParseStandardFlags() error = %v", err)
+	}
+
+	got := config.GetOutputConfiguration().ResolvedTargets()
+	if len(got) != 1 || got[0].Format != "json" || got[0].Path != "" {
+		t.Errorf("ResolvedTargets() = %+v, want a single json target on stdout", got)
+	}
+}
+
 func TestParseStandardFlags_ShouldFailOnInvalidFormat(t *testing.T) {
 	cmd := &cobra.Command{}
 	config.AddStandardFlags(cmd)
@@ -63,3 +91,112 @@ func TestParseStandardFlags_ShouldFailOnInvalidFormat(t *testing.T) {
 		t.Errorf("expected error, got none")
 	}
 }
+
+func TestParseStandardFlags_TemplateFormatRequiresTemplate(t *testing.T) {
+	cmd := &cobra.Command{}
+	config.AddStandardFlags(cmd)
+
+	setupErr := cmd.Flags().Set("format", "template")
+	if setupErr != nil {
+		t.Errorf("setup error: %v", setupErr)
+	}
+
+	err := config.ParseStandardFlags(cmd)
+	if err == nil {
+		t.Errorf("expected error, got none")
+	}
+
+	setupErr = cmd.Flags().Set("template", "report.tmpl")
+	if setupErr != nil {
+		t.Errorf("setup error: %v", setupErr)
+	}
+
+	err = config.ParseStandardFlags(cmd)
+	if err != nil {
+		t.Errorf("ParseStandardFlags() error = %v", err)
+	}
+	if got := config.GetOutputConfiguration().Template; got != "report.tmpl" {
+		t.Errorf("template = %q, want %q", got, "report.tmpl")
+	}
+}
+
+func TestParseOutputTarget(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    config.OutputTarget
+		wantErr bool
+	}{
+		{value: "educational", want: config.OutputTarget{Format: "educational"}},
+		{value: "json=tfcoach.json", want: config.OutputTarget{Format: "json", Path: "tfcoach.json"}},
+		{value: "sarif=-", want: config.OutputTarget{Format: "sarif"}},
+		{value: "abcd=out.txt", wantErr: true},
+		{value: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := config.ParseOutputTarget(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOutputTarget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseOutputTarget() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputConfiguration_ResolvedTargets(t *testing.T) {
+	yes := config.NullableBool{HasValue: true, IsTrue: true}
+	no := config.NullableBool{HasValue: true, IsTrue: false}
+
+	t.Run("no targets", func(t *testing.T) {
+		outputConfig := config.OutputConfiguration{Format: "pretty", Color: yes, Emojis: no}
+		got := outputConfig.ResolvedTargets()
+		want := []config.OutputTarget{{Format: "pretty", Color: yes, Emojis: no}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ResolvedTargets() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("several targets", func(t *testing.T) {
+		outputConfig := config.OutputConfiguration{
+			Format: "pretty",
+			Color:  yes,
+			Emojis: yes,
+			Targets: []config.OutputTarget{
+				{Format: "educational"},
+				{Format: "compact", Color: no, Emojis: no},
+				{Format: "json", Path: "tfcoach.json", Color: yes},
+			},
+		}
+		got := outputConfig.ResolvedTargets()
+		want := []config.OutputTarget{
+			{Format: "educational", Color: yes, Emojis: yes},
+			{Format: "compact", Color: no, Emojis: no},
+			{Format: "json", Path: "tfcoach.json", Color: no, Emojis: yes},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ResolvedTargets() = %+v, want %+v", got, want)
+		}
+	})
+}
+
+func TestNullableBool_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		value config.NullableBool
+		want  string
+	}{
+		{value: config.NullableBool{}, want: "null"},
+		{value: config.NullableBool{HasValue: true, IsTrue: false}, want: "false"},
+		{value: config.NullableBool{HasValue: true, IsTrue: true}, want: "true"},
+	}
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.value)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("json.Marshal(%+v) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
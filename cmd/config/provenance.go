@@ -0,0 +1,96 @@
+package config
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Sources of configuration values, in the order they are applied.
+const (
+	SourceDefault = "default"
+	SourceHome    = "home"
+	SourceLocal   = "local"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+)
+
+var (
+	// layers loaded by LoadConfig, in the order they were merged
+	layers []Layer
+	// flagPaths are the configuration paths overridden by command flags
+	flagPaths []string
+)
+
+// Layer is one source of the configuration loaded by LoadConfig.
+type Layer struct {
+	Source string
+	// Path is the file of the layer, empty for the default and env layers.
+	Path string
+	// Err tells why the layer was not used, e.g. because there is no such file.
+	Err  error
+	data config
+}
+
+// GetLayers returns the layers of the loaded configuration, in the order they were merged.
+func GetLayers() []Layer {
+	return slices.Clone(layers)
+}
+
+// Provenance maps the paths of configuration values to the source that set them last, e.g. "output.format" to
+// "local". Rule entries are merged field by field, so their paths are e.g. "rules.core.file_naming.severity" and
+// "rules.core.file_naming.spec.blocks", while lists are only set as a whole, e.g. "output.targets". Values no source
+// has set are missing.
+func Provenance() map[string]string {
+	provenance := make(map[string]string)
+	for _, layer := range layers {
+		if layer.Err != nil {
+			continue
+		}
+		collectSetPaths(reflect.ValueOf(layer.data), "", func(path string) {
+			provenance[path] = layer.Source
+		})
+	}
+	for _, path := range flagPaths {
+		provenance[path] = SourceFlag
+	}
+	return provenance
+}
+
+// collectSetPaths calls set with the path of every value in v that is not empty, which are the values mergo merges.
+func collectSetPaths(v reflect.Value, prefix string, set func(path string)) {
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		path := prefix + name
+		value := v.Field(i)
+		switch {
+		case field.Type == reflect.TypeFor[NullableBool]():
+			if value.Interface().(NullableBool).HasValue {
+				set(path)
+			}
+		case field.Type.Kind() == reflect.Struct:
+			collectSetPaths(value, path+".", set)
+		case field.Type.Kind() == reflect.Map:
+			for _, key := range value.MapKeys() {
+				if entry := value.MapIndex(key); entry.Kind() == reflect.Struct {
+					collectSetPaths(entry, path+"."+key.String()+".", set)
+				} else {
+					set(path + "." + key.String())
+				}
+			}
+		case field.Type.Kind() == reflect.Slice:
+			if value.Len() > 0 {
+				set(path)
+			}
+		case !value.IsZero():
+			set(path)
+		}
+	}
+}
+
+func markSetByFlag(path string) {
+	if !slices.Contains(flagPaths, path) {
+		flagPaths = append(flagPaths, path)
+	}
+}
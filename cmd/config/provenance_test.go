@@ -0,0 +1,73 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestProvenance(t *testing.T) {
+	homeDir := t.TempDir()
+	homeConfigDir := filepath.Join(homeDir, ".tfcoach")
+	_ = os.MkdirAll(homeConfigDir, 0777)
+	_ = os.WriteFile(filepath.Join(homeConfigDir, ".tfcoach.yml"), []byte("rules:\n  RULE_1:\n    enabled: false\n  RULE_2:\n    severity: low\n    spec:\n      max_length: 32\n      preset: camelCase\noutput:\n  format: compact\n  color: false\n"), 0644)
+	dir := t.TempDir()
+	t.Chdir(dir)
+	_ = os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), []byte("rules:\n  RULE_2:\n    enabled: false\n    spec:\n      max_length: 64\noutput:\n  color: true\n"), 0644)
+	t.Setenv("TFCOACH_OUTPUT_FORMAT", "json")
+
+	if err := LoadConfig(&navigatorMock{homeDir: homeDir}); err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	OverrideMaxIssues(3)
+
+	want := map[string]string{
+		"rules.RULE_1.enabled":            SourceHome,
+		"rules.RULE_2.enabled":            SourceLocal,
+		"rules.RULE_2.severity":           SourceHome,
+		"rules.RULE_2.spec.max_length":    SourceLocal,
+		"rules.RULE_2.spec.preset":        SourceHome,
+		"output.format":                   SourceEnv,
+		"output.color":                    SourceLocal,
+		"output.emojis":                   SourceDefault,
+		"output.include_terragrunt_cache": SourceDefault,
+		"output.fail_on":                  SourceDefault,
+		"output.max_issues":               SourceFlag,
+	}
+	if got := Provenance(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Provenance() = %v, want %v", got, want)
+	}
+
+	gotSources := make([]string, 0, len(GetLayers()))
+	for _, layer := range GetLayers() {
+		gotSources = append(gotSources, layer.Source)
+	}
+	wantSources := []string{SourceDefault, SourceHome, SourceLocal, SourceEnv}
+	if !reflect.DeepEqual(gotSources, wantSources) {
+		t.Errorf("GetLayers() sources = %v, want %v", gotSources, wantSources)
+	}
+}
+
+func TestProvenance_IgnoresInvalidLayers(t *testing.T) {
+	SetLenient(true)
+	t.Cleanup(func() {
+		SetLenient(false)
+	})
+	dir := t.TempDir()
+	t.Chdir(dir)
+	_ = os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), []byte("output: {::: x}\n"), 0644)
+
+	if err := LoadConfig(&navigatorMock{homeDir: t.TempDir()}); err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if got := Provenance()["output.format"]; got != SourceDefault {
+		t.Errorf("expected the invalid local config to be ignored, got source %q", got)
+	}
+	for _, layer := range GetLayers() {
+		if layer.Source == SourceLocal && layer.Err == nil {
+			t.Errorf("expected an error for the invalid local config")
+		}
+	}
+}
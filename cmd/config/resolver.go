@@ -0,0 +1,167 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/codeglyph/go-dotignore/v2"
+)
+
+// RuleResolver resolves the rule configuration of single files. The rules of the loaded configuration are changed by
+// its overrides, then by the rules and overrides of each nested configuration file from the outermost to the innermost
+// directory.
+type RuleResolver struct {
+	workingDir string
+	local      *configLayer
+	nested     map[string]*configLayer // config path -> layer, nil for skipped files
+}
+
+// configLayer holds the parts of one configuration file that apply to files below dir.
+type configLayer struct {
+	dir       string
+	rules     RuleConfigurations
+	overrides []compiledOverride
+}
+
+type compiledOverride struct {
+	matcher *dotignore.PatternMatcher
+	rules   map[string]PartialRuleConfiguration
+}
+
+// NewRuleResolver creates a resolver for the loaded configuration, whose overrides are relative to the current
+// directory.
+func NewRuleResolver() (*RuleResolver, error) {
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	local, err := newConfigLayer(workingDir, configuration)
+	if err != nil {
+		return nil, err
+	}
+	return &RuleResolver{
+		workingDir: workingDir,
+		local:      local,
+		nested:     make(map[string]*configLayer),
+	}, nil
+}
+
+// Resolve returns the rule configurations for file, which is below the given configuration files ordered from the
+// outermost to the innermost directory. Their rules are merged over those of the loaded configuration, the same way
+// the local configuration is merged over the global one. Files in the current directory or its parents are skipped,
+// they are not below the local configuration. Nested files cannot change the severity of a rule, and their output
+// configuration is ignored.
+func (r *RuleResolver) Resolve(file string, configPaths []string) (RuleConfigurations, error) {
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := config{Rules: maps.Clone(r.local.rules)}
+	if resolved.Rules == nil {
+		resolved.Rules = RuleConfigurations{}
+	}
+	r.local.applyOverrides(absFile, resolved.Rules)
+	for _, configPath := range configPaths {
+		layer, loadErr := r.nestedLayer(configPath)
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		if layer == nil {
+			continue
+		}
+		if mergeErr := mergeInto(&resolved, config{Rules: layer.rules}); mergeErr != nil {
+			return nil, mergeErr
+		}
+		layer.applyOverrides(absFile, resolved.Rules)
+	}
+	return resolved.Rules, nil
+}
+
+// nestedLayer loads a nested configuration file once. The result is nil if the file is skipped.
+func (r *RuleResolver) nestedLayer(configPath string) (*configLayer, error) {
+	absPath, err := filepath.Abs(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if layer, loaded := r.nested[absPath]; loaded {
+		return layer, nil
+	}
+	if rel, relErr := filepath.Rel(filepath.Dir(absPath), r.workingDir); relErr == nil && !isParentRel(rel) {
+		r.nested[absPath] = nil
+		return nil, nil
+	}
+
+	nestedConfigData, err := loadCustomConfigFromFile(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not load config %s: %w", configPath, err)
+	}
+	if !reflect.ValueOf(nestedConfigData.Output).IsZero() {
+		slog.Warn("ignoring output configuration of nested config", "path", configPath)
+	}
+	var errs []error
+	for _, ruleID := range slices.Sorted(maps.Keys(nestedConfigData.Rules)) {
+		if nestedConfigData.Rules[ruleID].Severity != "" {
+			errs = append(errs, fmt.Errorf(
+				"severity of rule %s can only be set in the local or global config, not in nested configs or the files they extend",
+				ruleID,
+			))
+		}
+	}
+	errs = append(errs, nestedConfigData.Rules.validate()...)
+	errs = append(errs, validateOverrides(nestedConfigData.Overrides)...)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid config %s: %w", configPath, errors.Join(errs...))
+	}
+
+	layer, err := newConfigLayer(filepath.Dir(absPath), nestedConfigData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", configPath, err)
+	}
+	r.nested[absPath] = layer
+	return layer, nil
+}
+
+func newConfigLayer(dir string, configData config) (*configLayer, error) {
+	layer := &configLayer{dir: dir, rules: configData.Rules}
+	for _, override := range configData.Overrides {
+		matcher, err := dotignore.NewPatternMatcher(override.Files)
+		if err != nil {
+			return nil, err
+		}
+		layer.overrides = append(layer.overrides, compiledOverride{matcher: matcher, rules: override.Rules})
+	}
+	return layer, nil
+}
+
+// applyOverrides changes the rule configurations by the overrides matching absFile, in the order they are declared.
+func (l *configLayer) applyOverrides(absFile string, ruleConfigurations RuleConfigurations) {
+	if len(l.overrides) == 0 {
+		return
+	}
+	rel, err := filepath.Rel(l.dir, absFile)
+	if err != nil || isParentRel(rel) {
+		return
+	}
+	rel = filepath.ToSlash(rel)
+	for _, override := range l.overrides {
+		if matched, matchErr := override.matcher.Matches(rel); matchErr != nil || !matched {
+			continue
+		}
+		for ruleID, partial := range override.rules {
+			ruleConfigurations[ruleID] = partial.applyTo(ruleConfigurations.ByID(ruleID))
+		}
+	}
+}
+
+// isParentRel reports whether a relative path leaves its base directory.
+func isParentRel(rel string) bool {
+	return rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,217 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Marcel2603/tfcoach/internal/types"
+)
+
+func resolveRules(t *testing.T, file string, configPaths ...string) (RuleConfigurations, error) {
+	t.Helper()
+	resolver, err := NewRuleResolver()
+	if err != nil {
+		t.Fatalf("NewRuleResolver() error = %v", err)
+	}
+	return resolver.Resolve(file, configPaths)
+}
+
+func TestRuleResolver_Overrides(t *testing.T) {
+	RegisterRuleOptions("test.configurable", []types.RuleOption{
+		{Name: "max_length", Type: types.RuleOptionNumber, Default: 64},
+		{Name: "preset", Type: types.RuleOptionString, Default: "snake_case"},
+	})
+	t.Cleanup(func() {
+		delete(ruleOptions, "test.configurable")
+	})
+	registerRules(t, "RULE_1", "RULE_2")
+
+	dir := t.TempDir()
+	_ = os.Chdir(dir)
+	content := `rules:
+  RULE_1:
+    enabled: false
+  test.configurable:
+    enabled: true
+    severity: info
+    spec:
+      preset: camelCase
+overrides:
+  - files: ["examples/**", "test/fixtures"]
+    rules:
+      RULE_1:
+        enabled: true
+      RULE_2:
+        enabled: false
+      test.configurable:
+        spec:
+          max_length: 80
+  - files: ["examples/legacy/*.tf"]
+    rules:
+      test.configurable:
+        enabled: false
+`
+	_ = os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), []byte(content), 0644)
+	if err := LoadConfig(&navigatorMock{homeDir: t.TempDir()}); err != nil {
+		t.Fatalf("Setup error: %v", err)
+	}
+
+	configured := RuleConfiguration{Enabled: NullableBool{HasValue: true, IsTrue: true}, Severity: "info", Spec: map[string]any{"preset": "camelCase"}}
+	relaxed := RuleConfiguration{Enabled: NullableBool{HasValue: true, IsTrue: true}, Severity: "info", Spec: map[string]any{"preset": "camelCase", "max_length": 80}}
+	tests := []struct {
+		file string
+		want RuleConfigurations
+	}{
+		{
+			file: "main.tf",
+			want: RuleConfigurations{"RULE_1": {Enabled: NullableBool{HasValue: true}}, "test.configurable": configured},
+		},
+		{
+			file: filepath.Join("examples", "basic", "main.tf"),
+			want: RuleConfigurations{"RULE_1": {Enabled: NullableBool{HasValue: true, IsTrue: true}}, "RULE_2": {Enabled: NullableBool{HasValue: true}}, "test.configurable": relaxed},
+		},
+		{
+			file: filepath.Join(dir, "modules", "test", "fixtures", "main.tf"),
+			want: RuleConfigurations{"RULE_1": {Enabled: NullableBool{HasValue: true, IsTrue: true}}, "RULE_2": {Enabled: NullableBool{HasValue: true}}, "test.configurable": relaxed},
+		},
+		{
+			file: filepath.Join("examples", "legacy", "main.tf"),
+			want: RuleConfigurations{
+				"RULE_1":            {Enabled: NullableBool{HasValue: true, IsTrue: true}},
+				"RULE_2":            {Enabled: NullableBool{HasValue: true}},
+				"test.configurable": {Enabled: NullableBool{HasValue: true}, Severity: "info", Spec: relaxed.Spec},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			got, err := resolveRules(t, tt.file)
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resolve() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if got := GetConfigByRuleID("test.configurable"); !reflect.DeepEqual(got, configured) {
+		t.Errorf("Resolve() changed the loaded config: %+v", got)
+	}
+}
+
+func TestRuleResolver_NestedConfigs(t *testing.T) {
+	RegisterRuleOptions("test.configurable", []types.RuleOption{
+		{Name: "max_length", Type: types.RuleOptionNumber, Default: 64},
+	})
+	t.Cleanup(func() {
+		delete(ruleOptions, "test.configurable")
+	})
+	registerRules(t, "RULE_1", "RULE_2")
+	RegisterRuleOptions("RULE_3", []types.RuleOption{
+		{Name: "max_length", Type: types.RuleOptionNumber, Default: 64},
+	})
+	t.Cleanup(func() {
+		delete(ruleOptions, "RULE_3")
+	})
+
+	dir := t.TempDir()
+	_ = os.Chdir(dir)
+	_ = os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), []byte("rules:\n  RULE_1:\n    enabled: false\n  RULE_2:\n    enabled: true\n    severity: info\n"), 0644)
+	if err := LoadConfig(&navigatorMock{homeDir: t.TempDir()}); err != nil {
+		t.Fatalf("Setup error: %v", err)
+	}
+	writeNestedConfig := func(t *testing.T, relPath string, content string) string {
+		t.Helper()
+		path := filepath.Join(dir, relPath)
+		_ = os.MkdirAll(filepath.Dir(path), 0755)
+		_ = os.WriteFile(path, []byte(content), 0644)
+		return path
+	}
+
+	legacy := writeNestedConfig(t, filepath.Join("legacy", ".tfcoach.yml"), "rules:\n  RULE_2:\n    enabled: false\n  RULE_3:\n    enabled: false\noverrides:\n  - files: [nested/*.tf]\n    rules:\n      RULE_1:\n        enabled: true\n")
+	nested := writeNestedConfig(t, filepath.Join("legacy", "nested", ".tfcoach.json"), `{"rules": {"RULE_3": {"enabled": true, "spec": {"max_length": 32}}}, "output": {"format": "json"}}`)
+
+	t.Run("merged over the loaded config", func(t *testing.T) {
+		got, err := resolveRules(t, filepath.Join(dir, "legacy", "nested", "main.tf"), legacy, nested)
+		if err != nil {
+			t.Fatalf("Resolve() error = %v", err)
+		}
+		want := RuleConfigurations{
+			"RULE_1": {Enabled: NullableBool{HasValue: true, IsTrue: true}},
+			"RULE_2": {Enabled: NullableBool{HasValue: true}, Severity: "info"},
+			"RULE_3": {Enabled: NullableBool{HasValue: true, IsTrue: true}, Spec: map[string]any{"max_length": float64(32)}},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Resolve() = %+v, want %+v", got, want)
+		}
+		if GetConfigByRuleID("RULE_2").Severity != "info" || !GetConfigByRuleID("RULE_2").IsEnabled() {
+			t.Errorf("Resolve() changed the loaded config: %+v", GetConfigByRuleID("RULE_2"))
+		}
+	})
+
+	t.Run("config of current directory is skipped", func(t *testing.T) {
+		got, err := resolveRules(t, "main.tf", filepath.Join(dir, ".tfcoach.yml"))
+		if err != nil {
+			t.Fatalf("Resolve() error = %v", err)
+		}
+		if got.ByID("RULE_2").Severity != "info" {
+			t.Errorf("wanted the rules of the loaded config, got %+v", got)
+		}
+	})
+
+	writeNestedConfig(t, "base.yml", "rules:\n  RULE_1:\n    severity: high\n")
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "severity", content: "rules:\n  RULE_1:\n    severity: high\n", wantErr: "severity of rule RULE_1 can only be set"},
+		{name: "invalid spec", content: "rules:\n  test.configurable:\n    spec:\n      max_length: long\n", wantErr: "invalid spec for rule test.configurable"},
+		{name: "invalid file", content: "rules: [", wantErr: "could not load config"},
+		{name: "severity of extended file", content: "extends: [../base.yml]\n", wantErr: "severity of rule RULE_1 can only be set in the local or global config, not in nested configs or the files they extend"},
+		{name: "unknown rule", content: "rules:\n  RULE_4:\n    enabled: false\n", wantErr: `unknown rule "RULE_4"`},
+		{name: "unknown key", content: "rules:\n  RULE_1:\n    enabeld: false\n", wantErr: `field enabeld not found`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeNestedConfig(t, filepath.Join(tt.name, ".tfcoach.yml"), tt.content)
+			_, err := resolveRules(t, filepath.Join(tt.name, "main.tf"), path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Resolve() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRuleResolver_NestedSpecOnlyRuleEntry(t *testing.T) {
+	RegisterRuleOptions("test.configurable", []types.RuleOption{
+		{Name: "max_length", Type: types.RuleOptionNumber, Default: 64},
+		{Name: "preset", Type: types.RuleOptionString, Default: "snake_case"},
+	})
+	t.Cleanup(func() {
+		delete(ruleOptions, "test.configurable")
+	})
+
+	dir := t.TempDir()
+	_ = os.Chdir(dir)
+	_ = os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), []byte("rules:\n  test.configurable:\n    spec:\n      preset: camelCase\n"), 0644)
+	if err := LoadConfig(&navigatorMock{homeDir: t.TempDir()}); err != nil {
+		t.Fatalf("Setup error: %v", err)
+	}
+	nestedPath := filepath.Join(dir, "legacy", ".tfcoach.yml")
+	_ = os.MkdirAll(filepath.Dir(nestedPath), 0755)
+	_ = os.WriteFile(nestedPath, []byte("rules:\n  test.configurable:\n    spec:\n      max_length: 80\n"), 0644)
+
+	got, err := resolveRules(t, filepath.Join(dir, "legacy", "main.tf"), nestedPath)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	want := RuleConfiguration{Spec: map[string]any{"preset": "camelCase", "max_length": 80}}
+	if ruleConfiguration := got.ByID("test.configurable"); !reflect.DeepEqual(ruleConfiguration, want) || !ruleConfiguration.IsEnabled() {
+		t.Errorf("Resolve() = %+v, want %+v", ruleConfiguration, want)
+	}
+}
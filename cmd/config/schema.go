@@ -0,0 +1,171 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/Marcel2603/tfcoach/internal/types"
+)
+
+const (
+	jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+	// ConfigSchemaURL is where the JSON Schema of configuration files is published, see docs/pages/schemas
+	ConfigSchemaURL = "https://marcel2603.github.io/tfcoach/schemas/config.json"
+)
+
+// schemaDescriptions documents the configuration values in the JSON Schema, by their path in the configuration.
+var schemaDescriptions = map[string]string{
+	"$schema":                         "JSON Schema of this file, for editors that support it",
+	"extends":                         "Presets and files this configuration is merged over, in the given order",
+	"rules":                           "Configuration of rules by rule ID",
+	"overrides":                       "Rule configurations for the files matching a pattern, applied in the given order",
+	"overrides[].files":               ".gitignore-style patterns, relative to the directory of the configuration file",
+	"overrides[].rules":               "Changes of the rule configurations for the matching files",
+	"output":                          "Output of lint runs",
+	"output.format":                   "Output format",
+	"output.color":                    "Use colors in the output",
+	"output.emojis":                   "Use emojis in the output",
+	"output.include_terragrunt_cache": "Lint the files in .terragrunt-cache directories",
+	"output.targets":                  "Reports written by a single lint run, replaces format",
+	"output.targets[].format":         "Output format of the report",
+	"output.targets[].path":           "File of the report, stdout if empty",
+	"output.targets[].color":          "Use colors in the report",
+	"output.targets[].emojis":         "Use emojis in the report",
+	"output.targets[].template":       "Go text/template file rendered by the template format",
+	"output.template":                 "Go text/template file rendered by the template format",
+	"output.fail_on":                  "Lowest severity of issues that fail the run",
+	"output.max_issues":               "Number of failing issues tolerated before the run fails",
+}
+
+// JSONSchema returns the JSON Schema of configuration files, which documents the given rules and their options.
+func JSONSchema(rules []types.Rule) ([]byte, error) {
+	generator := schemaGenerator{rules: rules}
+	schema := generator.schemaFor(reflect.TypeFor[config](), "")
+	schema["$schema"] = jsonSchemaDialect
+	schema["$id"] = ConfigSchemaURL
+	schema["title"] = "tfcoach configuration"
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+type schemaGenerator struct {
+	rules []types.Rule
+}
+
+// schemaFor returns the schema of a configuration value of type typ, path being its path in the configuration.
+func (g schemaGenerator) schemaFor(typ reflect.Type, path string) map[string]any {
+	var schema map[string]any
+	switch {
+	case path == "rules":
+		schema = g.rulesSchema(true)
+	case path == "overrides[].rules":
+		schema = g.rulesSchema(false)
+	case typ == reflect.TypeFor[NullableBool]():
+		schema = map[string]any{"type": "boolean"}
+	case typ.Kind() == reflect.Struct:
+		properties := make(map[string]any)
+		for name, field := range yamlFields(typ) {
+			properties[name] = g.schemaFor(field.Type, joinPath(path, name))
+		}
+		schema = map[string]any{"type": "object", "properties": properties, "additionalProperties": false}
+	case typ.Kind() == reflect.Slice:
+		schema = map[string]any{"type": "array", "items": g.schemaFor(typ.Elem(), path+"[]")}
+	case typ.Kind() == reflect.Map:
+		schema = map[string]any{"type": "object"}
+	case typ.Kind() == reflect.Bool:
+		schema = map[string]any{"type": "boolean"}
+	case typ.Kind() == reflect.Int:
+		schema = map[string]any{"type": "integer"}
+	default:
+		schema = map[string]any{"type": "string"}
+	}
+
+	switch path {
+	case "extends[]":
+		schema = map[string]any{"anyOf": []any{map[string]any{"enum": PresetNames()}, schema}}
+	case "overrides[]":
+		schema["required"] = []string{"files"}
+	case "overrides[].files":
+		schema["minItems"] = 1
+	case "output.format", "output.targets[].format":
+		schema["enum"] = supportedOutputFormats
+	case "output.targets[]":
+		schema["required"] = []string{"format"}
+	case "output.fail_on":
+		schema["enum"] = supportedFailOnValues
+	case "output.max_issues":
+		schema["minimum"] = 0
+	}
+	if description, found := schemaDescriptions[path]; found {
+		schema["description"] = description
+	}
+	return schema
+}
+
+// rulesSchema returns the schema of a rules map. Partial rule configurations, as used in overrides, have no severity.
+func (g schemaGenerator) rulesSchema(withSeverity bool) map[string]any {
+	properties := make(map[string]any, len(g.rules))
+	for _, rule := range g.rules {
+		ruleProperties := map[string]any{
+			"enabled": map[string]any{"type": "boolean", "description": "Enable the rule"},
+			"spec":    g.specSchema(rule),
+		}
+		if withSeverity {
+			ruleProperties["severity"] = map[string]any{
+				"enum":        []string{"high", "medium", "low", "info"},
+				"description": "Severity of the issues of the rule, instead of its default severity",
+			}
+		}
+		properties[rule.ID()] = map[string]any{
+			"title":                rule.META().Title,
+			"description":          rule.META().Description,
+			"type":                 "object",
+			"properties":           ruleProperties,
+			"additionalProperties": false,
+		}
+	}
+	return map[string]any{"type": "object", "properties": properties, "additionalProperties": false}
+}
+
+func (schemaGenerator) specSchema(rule types.Rule) map[string]any {
+	properties := make(map[string]any)
+	if configurable, ok := rule.(types.ConfigurableRule); ok {
+		for _, option := range configurable.Options() {
+			properties[option.Name] = optionSchema(option)
+		}
+	}
+	return map[string]any{
+		"description":          "Options of the rule",
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+func optionSchema(option types.RuleOption) map[string]any {
+	stringList := map[string]any{"type": "array", "items": map[string]any{"type": "string"}}
+	var schema map[string]any
+	switch option.Type {
+	case types.RuleOptionNumber:
+		schema = map[string]any{"type": "integer"}
+	case types.RuleOptionBool:
+		schema = map[string]any{"type": "boolean"}
+	case types.RuleOptionStringList:
+		schema = stringList
+	case types.RuleOptionStringMap:
+		schema = map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}}
+	case types.RuleOptionStringListMap:
+		schema = map[string]any{"type": "object", "additionalProperties": stringList}
+	default:
+		schema = map[string]any{"type": "string"}
+		if len(option.Allowed) > 0 {
+			schema["enum"] = option.Allowed
+		}
+	}
+	if option.Description != "" {
+		schema["description"] = option.Description
+	}
+	if option.Default != nil {
+		schema["default"] = option.Default
+	}
+	return schema
+}
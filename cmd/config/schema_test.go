@@ -0,0 +1,132 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Marcel2603/tfcoach/internal/constants"
+	"github.com/Marcel2603/tfcoach/internal/types"
+	"github.com/hashicorp/hcl/v2"
+	"gopkg.in/yaml.v3"
+)
+
+type schemaTestRule struct{}
+
+func (schemaTestRule) ID() string { return "test.configurable" }
+
+func (schemaTestRule) META() types.RuleMeta {
+	return types.RuleMeta{Title: "Configurable", Description: "A rule with options", Severity: constants.SeverityLow}
+}
+
+func (schemaTestRule) Apply(_ string, _ *hcl.File) []types.Issue { return nil }
+
+func (schemaTestRule) Finish() []types.Issue { return nil }
+
+func (schemaTestRule) Options() []types.RuleOption {
+	return []types.RuleOption{
+		{Name: "max_length", Type: types.RuleOptionNumber, Default: 64, Description: "Maximum length"},
+		{Name: "style", Type: types.RuleOptionString, Default: "snake", Allowed: []string{"snake", "kebab"}},
+	}
+}
+
+func (r schemaTestRule) WithOptions(_ types.RuleOptions) types.Rule { return r }
+
+func generateSchema(t *testing.T) map[string]any {
+	t.Helper()
+	data, err := JSONSchema([]types.Rule{schemaTestRule{}})
+	if err != nil {
+		t.Fatalf("JSONSchema() error = %v", err)
+	}
+	var schema map[string]any
+	if err = json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("JSONSchema() returned invalid JSON: %v", err)
+	}
+	return schema
+}
+
+// schemaAt returns the schema at a path of property names, "[]" stepping into the items of an array.
+func schemaAt(t *testing.T, schema map[string]any, path ...string) map[string]any {
+	t.Helper()
+	for _, name := range path {
+		var next any
+		if name == "[]" {
+			next = schema["items"]
+		} else {
+			properties, _ := schema["properties"].(map[string]any)
+			next = properties[name]
+		}
+		var ok bool
+		if schema, ok = next.(map[string]any); !ok {
+			t.Fatalf("no schema for %v", path)
+		}
+	}
+	return schema
+}
+
+func TestJSONSchema(t *testing.T) {
+	schema := generateSchema(t)
+
+	if schema["$id"] != ConfigSchemaURL {
+		t.Errorf("$id = %v, want %v", schema["$id"], ConfigSchemaURL)
+	}
+	rule := schemaAt(t, schema, "rules", "test.configurable")
+	if rule["description"] != "A rule with options" || rule["additionalProperties"] != false {
+		t.Errorf("unexpected rule schema: %v", rule)
+	}
+	maxLength := schemaAt(t, rule, "spec", "max_length")
+	if want := map[string]any{"type": "integer", "default": float64(64), "description": "Maximum length"}; !reflect.DeepEqual(maxLength, want) {
+		t.Errorf("max_length = %v, want %v", maxLength, want)
+	}
+	if style := schemaAt(t, rule, "spec", "style"); !reflect.DeepEqual(style["enum"], []any{"snake", "kebab"}) {
+		t.Errorf("expected the allowed values of style as enum, got %v", style)
+	}
+	if format := schemaAt(t, schema, "output", "format"); len(format["enum"].([]any)) != len(supportedOutputFormats) {
+		t.Errorf("expected the supported formats as enum, got %v", format)
+	}
+	overrideRule := schemaAt(t, schema, "overrides", "[]", "rules", "test.configurable")
+	if _, found := overrideRule["properties"].(map[string]any)["severity"]; found {
+		t.Errorf("expected no severity in overrides, got %v", overrideRule)
+	}
+}
+
+// TestJSONSchema_ShippedConfigs checks that the default config and the presets only use keys known to the schema.
+func TestJSONSchema_ShippedConfigs(t *testing.T) {
+	schema := generateSchema(t)
+	files := map[string][]byte{"default": yamlDefaultData}
+	for _, name := range PresetNames() {
+		files[name], _ = presetFiles.ReadFile(presetDir + "/" + name + presetExtension)
+	}
+	for name, data := range files {
+		t.Run(name, func(t *testing.T) {
+			var document map[string]any
+			if err := yaml.Unmarshal(data, &document); err != nil {
+				t.Fatalf("invalid YAML: %v", err)
+			}
+			checkKnownKeys(t, schema, document, name)
+		})
+	}
+}
+
+// checkKnownKeys reports the keys of value that are not properties of schema. The rule IDs are not checked, as the
+// schema of the test only knows a test rule.
+func checkKnownKeys(t *testing.T, schema map[string]any, value any, path string) {
+	t.Helper()
+	object, isObject := value.(map[string]any)
+	if !isObject || schema["additionalProperties"] != false {
+		return
+	}
+	properties, _ := schema["properties"].(map[string]any)
+	for key, child := range object {
+		if strings.HasSuffix(path, ".rules") {
+			continue
+		}
+		childSchema, known := properties[key].(map[string]any)
+		if !known {
+			t.Errorf("unknown key %s.%s", path, key)
+			continue
+		}
+		checkKnownKeys(t, childSchema, child, path+"."+key)
+	}
+}
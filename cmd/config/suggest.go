@@ -0,0 +1,94 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+)
+
+// unknownFieldPattern matches the unknown keys in the errors of the strict YAML and JSON decoders
+var unknownFieldPattern = regexp.MustCompile(`field (\S+) not found in type \S+|unknown field "([^"]+)"`)
+
+// didYouMean returns a hint naming the candidate closest to name, or an empty string if none is close enough.
+func didYouMean(name string, candidates []string) string {
+	best, bestDistance := "", len(name)/3+1
+	for _, candidate := range slices.Sorted(slices.Values(candidates)) {
+		if distance := editDistance(name, candidate); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+// editDistance is the number of inserted, deleted, replaced or swapped adjacent characters to turn a into b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	// rows i-2, i-1 and i of the distances between the prefixes of a and b
+	previous2 := make([]int, len(rb)+1)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				current[j] = min(current[j], previous2[j-2]+1)
+			}
+		}
+		previous2, previous, current = previous, current, previous2
+	}
+	return previous[len(rb)]
+}
+
+// unknownRuleError returns the error for a rule ID that is not known, with a hint for the closest known rule.
+func unknownRuleError(ruleID string) error {
+	return fmt.Errorf("unknown rule %q%s", ruleID, didYouMean(ruleID, slices.Collect(maps.Keys(ruleOptions))))
+}
+
+// withKeySuggestions adds hints for the misspelled keys to the error of a strict decoder.
+func withKeySuggestions(err error) error {
+	message := err.Error()
+	if !unknownFieldPattern.MatchString(message) {
+		return err
+	}
+	keys := configKeys(reflect.TypeFor[config](), nil)
+	message = unknownFieldPattern.ReplaceAllStringFunc(message, func(match string) string {
+		groups := unknownFieldPattern.FindStringSubmatch(match)
+		key := groups[1] + groups[2]
+		return match + didYouMean(key, keys)
+	})
+	return errors.New(message)
+}
+
+// configKeys returns the keys of a configuration type and of all types nested in it.
+func configKeys(typ reflect.Type, keys []string) []string {
+	switch typ.Kind() {
+	case reflect.Map, reflect.Slice:
+		return configKeys(typ.Elem(), keys)
+	case reflect.Struct:
+		if typ == reflect.TypeFor[NullableBool]() {
+			return keys
+		}
+		for name, field := range yamlFields(typ) {
+			if !slices.Contains(keys, name) {
+				keys = append(keys, name)
+			}
+			keys = configKeys(field.Type, keys)
+		}
+	default:
+	}
+	return keys
+}
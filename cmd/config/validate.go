@@ -0,0 +1,307 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is an error found in a configuration file. Line and Column are 0 if the location is unknown.
+type Problem struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.Line == 0 {
+		return fmt.Sprintf("%s: %s", p.File, p.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", p.File, p.Line, p.Column, p.Message)
+}
+
+// ValidateFile checks a configuration file and the files it extends for unknown keys and values of the wrong type.
+// Then it loads the file over the defaults and runs the checks that lint runs on the result. The error is only set if
+// the file cannot be read.
+func ValidateFile(configPath string) ([]Problem, error) {
+	v := &fileValidator{}
+	if err := v.checkFile(configPath, nil); err != nil {
+		return nil, err
+	}
+	if len(v.problems) == 0 {
+		v.checkValues(configPath)
+	}
+	return v.problems, nil
+}
+
+type fileValidator struct {
+	file     string
+	problems []Problem
+	// documents are the parsed files, the validated file first, to locate the values rejected by config.Validate
+	documents []parsedFile
+}
+
+type parsedFile struct {
+	path string
+	root *yaml.Node
+}
+
+func (v *fileValidator) checkFile(configPath string, chain []string) error {
+	absPath, err := filepath.Abs(configPath)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+
+	v.file = configPath
+	switch filepath.Ext(configPath) {
+	case ".tfcoach", ".json", ".yaml", ".yml":
+	default:
+		v.problems = append(v.problems, Problem{File: configPath, Message: "unsupported file extension, use .yml, .yaml or .json"})
+		return nil
+	}
+
+	// JSON is valid YAML, so both formats are checked on the YAML node tree to know the location of every value
+	var document yaml.Node
+	if err = yaml.Unmarshal(data, &document); err != nil {
+		v.problems = append(v.problems, Problem{File: configPath, Message: err.Error()})
+		return nil
+	}
+	if len(document.Content) == 0 {
+		return nil
+	}
+	root := document.Content[0]
+	v.documents = append(v.documents, parsedFile{path: configPath, root: root})
+	if !v.checkStructure(root, reflect.TypeFor[config](), "") {
+		return nil
+	}
+	return v.checkExtends(root, append(chain, absPath))
+}
+
+// checkValues loads the file the way lint does and reports the errors of config.Validate, at the location of the
+// rejected value if it is set in one of the files.
+func (v *fileValidator) checkValues(configPath string) {
+	v.file = configPath
+	var configData config
+	err := loadConfigFromYaml(yamlDefaultData, &configData)
+	if err == nil {
+		var fileData config
+		fileData, err = loadCustomConfigFromFile(configPath)
+		if err == nil {
+			err = mergeInto(&configData, fileData)
+		}
+	}
+	if err != nil {
+		v.problems = append(v.problems, Problem{File: configPath, Message: err.Error()})
+		return
+	}
+
+	for _, err = range configData.validate() {
+		problem := Problem{File: configPath, Message: err.Error()}
+		var valueErr valueError
+		if errors.As(err, &valueErr) {
+			for _, document := range v.documents {
+				if node := locate(document.root, valueErr.path); node != nil {
+					problem = Problem{File: document.path, Line: node.Line, Column: node.Column, Message: err.Error()}
+					break
+				}
+			}
+		}
+		v.problems = append(v.problems, problem)
+	}
+}
+
+// locate returns the node of the value at path, or nil if the document does not set it. Maps and lists are located by
+// their key, if they have one.
+func locate(root *yaml.Node, path []string) *yaml.Node {
+	located := root
+	node := root
+	for _, key := range path {
+		var keyNode, valueNode *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			keyNode, valueNode = mappingEntry(node, key)
+		case yaml.SequenceNode:
+			if index, err := strconv.Atoi(key); err == nil && index < len(node.Content) {
+				valueNode = node.Content[index]
+			}
+		}
+		if valueNode == nil {
+			return nil
+		}
+		located = valueNode
+		if keyNode != nil && valueNode.Kind != yaml.ScalarNode {
+			located = keyNode
+		}
+		node = valueNode
+	}
+	return located
+}
+
+func (v *fileValidator) add(node *yaml.Node, format string, args ...any) {
+	v.problems = append(v.problems, Problem{
+		File:    v.file,
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// checkStructure reports unknown keys and values of the wrong type. The result is false if there were problems.
+func (v *fileValidator) checkStructure(node *yaml.Node, typ reflect.Type, path string) bool {
+	if node.Tag == "!!null" {
+		return true
+	}
+	valid := true
+	switch {
+	case typ == reflect.TypeFor[NullableBool]():
+		return v.checkScalar(node, reflect.TypeFor[bool](), path)
+	case typ.Kind() == reflect.Struct:
+		if !v.checkKind(node, yaml.MappingNode, path, "a map") {
+			return false
+		}
+		fields := yamlFields(typ)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			field, ok := fields[key.Value]
+			if !ok {
+				v.add(key, "unknown key %q in %s%s", key.Value, describePath(path), didYouMean(key.Value, slices.Collect(maps.Keys(fields))))
+				valid = false
+				continue
+			}
+			valid = v.checkStructure(value, field.Type, joinPath(path, key.Value)) && valid
+		}
+	case typ.Kind() == reflect.Map:
+		if !v.checkKind(node, yaml.MappingNode, path, "a map") {
+			return false
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			valid = v.checkStructure(node.Content[i+1], typ.Elem(), joinPath(path, node.Content[i].Value)) && valid
+		}
+	case typ.Kind() == reflect.Slice:
+		if !v.checkKind(node, yaml.SequenceNode, path, "a list") {
+			return false
+		}
+		for i, item := range node.Content {
+			valid = v.checkStructure(item, typ.Elem(), fmt.Sprintf("%s[%d]", path, i)) && valid
+		}
+	case typ.Kind() == reflect.Interface:
+	default:
+		return v.checkScalar(node, typ, path)
+	}
+	return valid
+}
+
+func (v *fileValidator) checkKind(node *yaml.Node, kind yaml.Kind, path string, description string) bool {
+	if node.Kind != kind {
+		v.add(node, "%s must be %s", path, description)
+		return false
+	}
+	return true
+}
+
+func (v *fileValidator) checkScalar(node *yaml.Node, typ reflect.Type, path string) bool {
+	if node.Kind != yaml.ScalarNode || node.Decode(reflect.New(typ).Interface()) != nil {
+		v.add(node, "%s must be %s", path, describeType(typ))
+		return false
+	}
+	return true
+}
+
+// checkExtends reports unknown presets and missing files, and checks the extended files.
+func (v *fileValidator) checkExtends(root *yaml.Node, chain []string) error {
+	_, extendsNode := mappingEntry(root, "extends")
+	if extendsNode == nil {
+		return nil
+	}
+	file := v.file
+	for _, refNode := range extendsNode.Content {
+		ref := refNode.Value
+		if isPresetRef(ref) {
+			if !slices.Contains(PresetNames(), ref) {
+				v.add(refNode, "unknown preset %q (available: %s)", ref, strings.Join(PresetNames(), ", "))
+			}
+			continue
+		}
+		if !filepath.IsAbs(ref) {
+			ref = filepath.Join(filepath.Dir(chain[len(chain)-1]), ref)
+		}
+		if slices.Contains(chain, ref) {
+			v.add(refNode, "cyclic extends: %s", strings.Join(append(chain, ref), " -> "))
+			continue
+		}
+		if _, err := os.Stat(ref); err != nil {
+			v.add(refNode, "cannot extend %s: %v", refNode.Value, err)
+			continue
+		}
+		if err := v.checkFile(ref, chain); err != nil {
+			return err
+		}
+		v.file = file
+	}
+	return nil
+}
+
+// mappingEntry returns the key and value nodes of key in a mapping node, or nil if there is no such key.
+func mappingEntry(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// yamlFields maps the YAML names of the fields of a struct type to the fields.
+func yamlFields(typ reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField, typ.NumField())
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		fields[name] = field
+	}
+	return fields
+}
+
+func describeType(typ reflect.Type) string {
+	switch typ.Kind() {
+	case reflect.Bool:
+		return "true or false"
+	case reflect.Int:
+		return "a number"
+	default:
+		return "a " + typ.Kind().String()
+	}
+}
+
+func describePath(path string) string {
+	if path == "" {
+		return "the config"
+	}
+	return path
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
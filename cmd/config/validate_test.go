@@ -0,0 +1,183 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Marcel2603/tfcoach/internal/types"
+)
+
+func TestValidateFile(t *testing.T) {
+	RegisterRuleOptions("test.configurable", []types.RuleOption{
+		{Name: "max_length", Type: types.RuleOptionNumber, Default: 64},
+	})
+	t.Cleanup(func() {
+		delete(ruleOptions, "test.configurable")
+	})
+
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		want     []string
+	}{
+		{
+			name:     "valid",
+			filename: ".tfcoach.yml",
+			content:  "rules:\n  test.configurable:\n    enabled: true\n    severity: high\n    spec:\n      max_length: 32\noutput:\n  format: compact\n",
+		},
+		{
+			name:     "typo in key",
+			filename: ".tfcoach.yml",
+			content:  "rules:\n  test.configurable:\n    enabeld: false\n",
+			want:     []string{`.tfcoach.yml:3:5: unknown key "enabeld" in rules.test.configurable (did you mean "enabled"?)`},
+		},
+		{
+			name:     "wrong type",
+			filename: ".tfcoach.yml",
+			content:  "output:\n  color: maybe\n  max_issues: many\n",
+			want: []string{
+				".tfcoach.yml:2:10: output.color must be true or false",
+				".tfcoach.yml:3:15: output.max_issues must be a number",
+			},
+		},
+		{
+			name:     "invalid values",
+			filename: ".tfcoach.yml",
+			content:  "rules:\n  test.unknown:\n    enabled: false\n  test.configurable:\n    severity: urgent\n    spec:\n      max_length: long\n      min_length: 3\noutput:\n  format: fancy\n",
+			want: []string{
+				`.tfcoach.yml:5:15: invalid severity for rule test.configurable: unknown severity "urgent" (want high|medium|low|info)`,
+				`.tfcoach.yml:6:5: invalid spec for rule test.configurable: invalid option "max_length": expected number, got long; unknown option "min_length" (supported: max_length)`,
+				`.tfcoach.yml:2:3: unknown rule "test.unknown"`,
+				`.tfcoach.yml:10:11: invalid format: "fancy" (supported: [json compact pretty educational sarif junit gitlab github checkstyle html markdown template])`,
+			},
+		},
+		{
+			name:     "overrides",
+			filename: ".tfcoach.yml",
+			content:  "overrides:\n  - rules:\n      test.unknown:\n        enabled: false\n",
+			want: []string{
+				".tfcoach.yml:2:5: invalid override 1: files must not be empty",
+				`.tfcoach.yml:3:7: invalid override 1: unknown rule "test.unknown"`,
+			},
+		},
+		{
+			name:     "override pattern",
+			filename: ".tfcoach.yml",
+			content:  "overrides:\n  - files: [\"**/[z-a]\"]\n",
+			want: []string{
+				`.tfcoach.yml:2:5: invalid override 1: failed to build ignore patterns: failed to build regex for pattern "**/[z-a]" at line 1: failed to compile regex "^(.*?/)?[z-a]$": error parsing regexp: invalid character class range: ` + "`z-a`",
+			},
+		},
+		{
+			name:     "template without template file",
+			filename: ".tfcoach.yml",
+			content:  "output:\n  format: template\n",
+			want:     []string{`.tfcoach.yml:2:11: invalid format: "template" requires a template`},
+		},
+		{
+			name:     "extends",
+			filename: ".tfcoach.yml",
+			content:  "extends: [lenient]\n",
+			want: []string{
+				`.tfcoach.yml:1:11: unknown preset "lenient" (available: minimal, recommended, strict)`,
+			},
+		},
+		{
+			name:     "json",
+			filename: ".tfcoach.json",
+			content:  "{\n  \"rules\": {\n    \"test.configurable\": {\"enabeld\": false}\n  }\n}\n",
+			want:     []string{`.tfcoach.json:3:27: unknown key "enabeld" in rules.test.configurable (did you mean "enabled"?)`},
+		},
+		{
+			name:     "unsupported extension",
+			filename: ".tfcoach.toml",
+			content:  "[rules]\n",
+			want:     []string{".tfcoach.toml: unsupported file extension, use .yml, .yaml or .json"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Chdir(dir)
+			_ = os.WriteFile(filepath.Join(dir, tt.filename), []byte(tt.content), 0644)
+
+			problems, err := ValidateFile(tt.filename)
+			if err != nil {
+				t.Fatalf("ValidateFile() error = %v", err)
+			}
+			var got []string
+			for _, problem := range problems {
+				got = append(got, problem.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateFile() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateFile_ExtendedFile(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, "base.yml"), []byte("output:\n  formt: json\n"), 0644)
+	_ = os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), []byte("extends: [./base.yml]\n"), 0644)
+
+	problems, err := ValidateFile(filepath.Join(dir, ".tfcoach.yml"))
+	if err != nil {
+		t.Fatalf("ValidateFile() error = %v", err)
+	}
+	if len(problems) != 1 || problems[0].File != filepath.Join(dir, "base.yml") || problems[0].Line != 2 {
+		t.Errorf("expected the typo in base.yml to be reported, got %v", problems)
+	}
+}
+
+func TestValidateFile_ValueOfExtendedFile(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, "base.yml"), []byte("output:\n  format: template\n"), 0644)
+	_ = os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), []byte("extends: [./base.yml]\noutput:\n  fail_on: critical\n"), 0644)
+
+	problems, err := ValidateFile(filepath.Join(dir, ".tfcoach.yml"))
+	if err != nil {
+		t.Fatalf("ValidateFile() error = %v", err)
+	}
+	var got []string
+	for _, problem := range problems {
+		got = append(got, problem.String())
+	}
+	want := []string{
+		filepath.Join(dir, "base.yml") + `:2:11: invalid format: "template" requires a template`,
+		filepath.Join(dir, ".tfcoach.yml") + `:3:12: invalid fail_on: "critical" (want high|medium|low|info|never)`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidateFile() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestValidateFile_InvalidExtends(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, ".tfcoach.yml")
+	_ = os.WriteFile(configPath, []byte("extends: [missing.yml, .tfcoach.yml]\n"), 0644)
+
+	problems, err := ValidateFile(configPath)
+	if err != nil {
+		t.Fatalf("ValidateFile() error = %v", err)
+	}
+	if len(problems) != 2 {
+		t.Fatalf("expected 2 problems, got %v", problems)
+	}
+	if !strings.HasPrefix(problems[0].Message, "cannot extend missing.yml") {
+		t.Errorf("expected the missing file to be reported, got %q", problems[0].Message)
+	}
+	if problems[1].Message != "cyclic extends: "+configPath+" -> "+configPath {
+		t.Errorf("expected the cycle to be reported, got %q", problems[1].Message)
+	}
+}
+
+func TestValidateFile_Missing(t *testing.T) {
+	if _, err := ValidateFile(filepath.Join(t.TempDir(), ".tfcoach.yml")); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Marcel2603/tfcoach/rules/core"
+)
+
+func TestConfigValidate(t *testing.T) {
+	dir := t.TempDir()
+	validPath := filepath.Join(dir, "valid.yml")
+	invalidPath := filepath.Join(dir, "invalid.yml")
+	_ = os.WriteFile(validPath, []byte("rules:\n  core.file_naming:\n    enabled: false\n"), 0644)
+	_ = os.WriteFile(invalidPath, []byte("rules:\n  core.file_naming:\n    enabeld: false\n"), 0644)
+
+	buf := new(bytes.Buffer)
+	valid, err := writeValidation(validPath, buf)
+	if err != nil || !valid {
+		t.Fatalf("expected %s to be valid, got %v: %s", validPath, err, buf)
+	}
+
+	buf.Reset()
+	valid, err = writeValidation(invalidPath, buf)
+	if err != nil || valid {
+		t.Fatalf("expected %s to be invalid, got %v: %s", invalidPath, err, buf)
+	}
+	want := invalidPath + ":3:5: unknown key \"enabeld\" in rules.core.file_naming (did you mean \"enabled\"?)\n1 problem found\n"
+	if got := buf.String(); got != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestConfigShow(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	_ = os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), []byte("rules:\n  core.file_naming:\n    enabled: false\noutput:\n  format: compact\n"), 0644)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"config", "show", "--format", "json"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := buf.String()
+	for _, want := range []string{
+		"# local: " + filepath.Join(dir, ".tfcoach.yml") + "\n",
+		"  core.file_naming:\n    enabled: false # local\n",
+		"  format: json # flag\n",
+		"  max_issues: 0 # default\n",
+		"  fail_on: low # default\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestConfigSchema(t *testing.T) {
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"config", "schema"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var schema struct {
+		Properties struct {
+			Rules struct {
+				Properties map[string]any `json:"properties"`
+			} `json:"rules"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &schema); err != nil {
+		t.Fatalf("expected a JSON schema, got %v: %s", err, buf)
+	}
+	for _, rule := range core.All() {
+		if _, found := schema.Properties.Rules.Properties[rule.ID()]; !found {
+			t.Errorf("expected rule %s in the schema", rule.ID())
+		}
+	}
+}
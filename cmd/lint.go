@@ -1,17 +1,27 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"os"
 
 	"github.com/Marcel2603/tfcoach/cmd/config"
 	"github.com/Marcel2603/tfcoach/internal/engine"
+	"github.com/Marcel2603/tfcoach/internal/engine/processor"
+	"github.com/Marcel2603/tfcoach/internal/formatter"
 	"github.com/Marcel2603/tfcoach/internal/runner"
+	"github.com/Marcel2603/tfcoach/internal/types"
 	"github.com/Marcel2603/tfcoach/rules/core"
 	"github.com/spf13/cobra"
 )
 
 var (
 	includeTgCacheFlag bool
+	outputFlags        []string
+	baselineFlag       string
+	pruneBaselineFlag  bool
+	failOnFlag         string
+	maxIssuesFlag      int
 )
 
 var lintCmd = &cobra.Command{
@@ -28,6 +38,36 @@ var lintCmd = &cobra.Command{
 			config.OverrideIncludeTgCache(includeTgCacheFlag)
 		}
 
+		if cmd.Flags().Changed("output") {
+			var targets []config.OutputTarget
+			for _, outputFlag := range outputFlags {
+				target, parseErr := config.ParseOutputTarget(outputFlag)
+				if parseErr != nil {
+					return parseErr
+				}
+				targets = append(targets, target)
+			}
+			config.OverrideTargets(targets)
+		}
+
+		if cmd.Flags().Changed("fail-on") {
+			if failOnErr := config.ValidateFailOn(failOnFlag); failOnErr != nil {
+				return failOnErr
+			}
+			config.OverrideFailOn(failOnFlag)
+		}
+
+		if cmd.Flags().Changed("max-issues") {
+			if maxIssuesFlag < 0 {
+				return errors.New("--max-issues must not be negative")
+			}
+			config.OverrideMaxIssues(maxIssuesFlag)
+		}
+
+		if pruneBaselineFlag && resolveBaselinePath(cmd) == "" {
+			return errors.New("--prune-baseline requires a baseline, create one with \"tfcoach baseline create\"")
+		}
+
 		return nil
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -38,18 +78,97 @@ var lintCmd = &cobra.Command{
 
 		finalOutputConfig := config.GetOutputConfiguration()
 
-		skipDirs := []string{".git", ".terraform"}
-		if !finalOutputConfig.IncludeTerragruntCache.IsTrue {
-			skipDirs = append(skipDirs, ".terragrunt-cache")
+		var outputs []runner.Output
+		for _, outputTarget := range finalOutputConfig.ResolvedTargets() {
+			outputs = append(outputs, runner.Output{
+				Format:   outputTarget.Format,
+				Path:     outputTarget.Path,
+				Color:    outputTarget.Color.IsTrue,
+				Emojis:   outputTarget.Emojis.IsTrue,
+				Template: outputTarget.Template,
+			})
+		}
+
+		runInfo := formatter.RunInfo{
+			BuildVersion: BuildVersion,
+			BuildCommit:  BuildCommit,
+			Config:       config.GetEffectiveConfiguration(),
 		}
 
-		src := engine.FileSystem{SkipDirs: skipDirs}
-		code := runner.Lint(target, src, core.EnabledRules(), cmd.OutOrStdout(), finalOutputConfig.Format, finalOutputConfig.Emojis.IsTrue)
+		baselineOptions := runner.BaselineOptions{Path: resolveBaselinePath(cmd), Prune: pruneBaselineFlag}
+		failPolicy := runner.FailPolicy{FailOn: finalOutputConfig.FailOn, MaxIssues: finalOutputConfig.MaxIssues}
+
+		enabledRules := core.EnabledRules()
+		ruleResolver, err := newRuleResolver(enabledRules)
+		if err != nil {
+			return err
+		}
+
+		code := runner.Lint(
+			target,
+			newSource(),
+			enabledRules,
+			ruleResolver,
+			cmd.OutOrStdout(),
+			outputs,
+			runInfo,
+			baselineOptions,
+			failPolicy,
+		)
 		os.Exit(code)
 		return nil
 	},
 }
 
+// newSource returns the file system source with the directories skipped according to the configuration.
+func newSource() engine.FileSystem {
+	skipDirs := []string{".git", ".terraform"}
+	if !config.GetOutputConfiguration().IncludeTerragruntCache.IsTrue {
+		skipDirs = append(skipDirs, ".terragrunt-cache")
+	}
+	return engine.FileSystem{SkipDirs: skipDirs, ConfigFileNames: config.StandardConfigFileNames()}
+}
+
+// newRuleResolver returns a resolver that applies the nested configuration files and the overrides to each file.
+// Files with the same rule configuration share their rules, which are enabledRules for the loaded configuration.
+func newRuleResolver(enabledRules []types.Rule) (engine.RuleResolver, error) {
+	resolver, err := config.NewRuleResolver()
+	if err != nil {
+		return nil, err
+	}
+	ruleSets := map[string][]types.Rule{ruleSetKey(config.GetRuleConfigurations()): enabledRules}
+	return func(file string, configFiles []string) ([]types.Rule, error) {
+		ruleConfigurations, resolveErr := resolver.Resolve(file, configFiles)
+		if resolveErr != nil {
+			return nil, resolveErr
+		}
+		key := ruleSetKey(ruleConfigurations)
+		rules, ok := ruleSets[key]
+		if !ok {
+			rules = core.EnabledRulesFor(ruleConfigurations)
+			ruleSets[key] = rules
+		}
+		return rules, nil
+	}, nil
+}
+
+// ruleSetKey identifies rule configurations, the JSON encoding has sorted map keys.
+func ruleSetKey(ruleConfigurations config.RuleConfigurations) string {
+	key, _ := json.Marshal(ruleConfigurations)
+	return string(key)
+}
+
+// resolveBaselinePath returns the baseline to use, which is the default baseline file only if it exists.
+func resolveBaselinePath(cmd *cobra.Command) string {
+	if cmd.Flags().Changed("baseline") {
+		return baselineFlag
+	}
+	if _, err := os.Stat(baselineFlag); err != nil {
+		return ""
+	}
+	return baselineFlag
+}
+
 func init() {
 	rootCmd.AddCommand(lintCmd)
 	config.AddStandardFlags(lintCmd)
@@ -61,7 +180,42 @@ func init() {
 		"Include Terragrunt cache in scanned files",
 	)
 
+	lintCmd.Flags().StringArrayVar(
+		&outputFlags,
+		"output",
+		nil,
+		"Write a report as format[=path], repeatable; without path the report goes to stdout (replaces --format)",
+	)
+
+	lintCmd.Flags().StringVar(
+		&baselineFlag,
+		"baseline",
+		processor.DefaultBaselineFileName,
+		"Baseline file, issues recorded in it are not reported (only used if it exists unless set explicitly)",
+	)
+
+	lintCmd.Flags().BoolVar(
+		&pruneBaselineFlag,
+		"prune-baseline",
+		false,
+		"Remove fixed issues from the baseline file",
+	)
+
+	lintCmd.Flags().StringVar(
+		&failOnFlag,
+		"fail-on",
+		config.GetOutputConfiguration().FailOn,
+		"Lowest severity of issues that fail the run with exit code 1 (high|medium|low|info|never)",
+	)
+
+	lintCmd.Flags().IntVar(
+		&maxIssuesFlag,
+		"max-issues",
+		config.GetOutputConfiguration().MaxIssues,
+		"Number of failing issues tolerated before the run fails",
+	)
+
 	lintCmd.Annotations = map[string]string{
-		"exitCodes": "0:No issues found,1:Issues found,2:Runtime error",
+		"exitCodes": "0:No failing issues found,1:Failing issues found (see --fail-on and --max-issues),2:Runtime error",
 	}
 }
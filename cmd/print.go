@@ -4,6 +4,7 @@ import (
 	"os"
 
 	"github.com/Marcel2603/tfcoach/cmd/config"
+	"github.com/Marcel2603/tfcoach/internal/formatter"
 	"github.com/Marcel2603/tfcoach/internal/runner"
 	"github.com/spf13/cobra"
 )
@@ -19,7 +20,11 @@ var printCmd = &cobra.Command{
 		srcReportPath := args[0]
 		finalOutputConfig := config.GetOutputConfiguration()
 
-		code := runner.Print(srcReportPath, cmd.OutOrStdout(), finalOutputConfig.Format, finalOutputConfig.Emojis.IsTrue)
+		options := formatter.Options{
+			AllowEmojis:  finalOutputConfig.Emojis.IsTrue,
+			TemplatePath: finalOutputConfig.Template,
+		}
+		code := runner.Print(srcReportPath, cmd.OutOrStdout(), finalOutputConfig.Format, options)
 		os.Exit(code)
 		return nil
 	},
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Marcel2603/tfcoach/cmd/config"
+	"github.com/Marcel2603/tfcoach/internal/types"
+	"github.com/Marcel2603/tfcoach/rules/core"
+	"github.com/spf13/cobra"
+)
+
+var rulesCmd = &cobra.Command{
+	Use:   "rules [rule_id]",
+	Short: "List the available rules, or show the details and options of one rule",
+	Args:  cobra.MaximumNArgs(1),
+	PreRunE: func(cmd *cobra.Command, _ []string) error {
+		// the severities shown are those of the configuration
+		return config.ParseStandardFlags(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return writeRuleList(cmd.OutOrStdout())
+		}
+
+		rule, err := core.FindByID(args[0])
+		if err != nil {
+			return err
+		}
+		return writeRuleDetails(rule, cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rulesCmd)
+	rulesCmd.Annotations = map[string]string{
+		"exitCodes": "0:OK,1:Unknown rule or invalid configuration",
+	}
+}
+
+func writeRuleList(w io.Writer) error {
+	rules := core.All()
+	slices.SortFunc(rules, func(a, b types.Rule) int {
+		return cmp.Compare(a.ID(), b.ID())
+	})
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tSEVERITY\tOPTIONS\tTITLE")
+	for _, rule := range rules {
+		meta := rule.META()
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", rule.ID(), meta.Severity, len(core.OptionsByID(rule.ID())), meta.Title)
+	}
+	return tw.Flush()
+}
+
+func writeRuleDetails(rule types.Rule, w io.Writer) error {
+	meta := rule.META()
+	_, _ = fmt.Fprintf(w, "%s (%s)\n%s\n\n%s\n\n", rule.ID(), meta.Severity, meta.Title, meta.Description)
+
+	options := core.OptionsByID(rule.ID())
+	if len(options) == 0 {
+		_, err := fmt.Fprintln(w, "This rule has no options.")
+		return err
+	}
+
+	_, _ = fmt.Fprintf(w, "Options (set under rules.%s.spec):\n", rule.ID())
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "  NAME\tTYPE\tDEFAULT\tDESCRIPTION")
+	for _, option := range options {
+		description := option.Description
+		if len(option.Allowed) > 0 {
+			description += fmt.Sprintf(" (one of %s)", strings.Join(option.Allowed, "|"))
+		}
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", option.Name, option.Type, option.DefaultString(), description)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRulesCommand_List(t *testing.T) {
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"rules"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := buf.String()
+	for _, want := range []string{"ID", "core.naming_convention", "core.file_naming"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in output, got: %q", want, got)
+		}
+	}
+}
+
+func TestRulesCommand_Details(t *testing.T) {
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"rules", "core.naming_convention"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := buf.String()
+	if !strings.HasPrefix(got, "core.naming_convention (HIGH)\nNaming Convention\n") {
+		t.Errorf("unexpected output: %q", got)
+	}
+}
+
+func TestRulesCommand_ConfiguredSeverity(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	_ = os.WriteFile(filepath.Join(dir, ".tfcoach.yml"), []byte("rules:\n  core.naming_convention:\n    severity: low\n"), 0644)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetArgs([]string{"rules", "core.naming_convention"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := buf.String(); !strings.HasPrefix(got, "core.naming_convention (LOW)\n") {
+		t.Errorf("expected the configured severity, got: %q", got)
+	}
+}
+
+func TestRulesCommand_UnknownRule(t *testing.T) {
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"rules", "core.does_not_exist"})
+
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatalf("expected an error for an unknown rule")
+	}
+}
@@ -1,11 +1,17 @@
 package constants
 
-import "github.com/Marcel2603/tfcoach/internal/types"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Marcel2603/tfcoach/internal/types"
+)
 
 var (
 	SeverityHigh    = types.Severity{Str: "HIGH", Priority: 1}
 	SeverityMedium  = types.Severity{Str: "MEDIUM", Priority: 2}
 	SeverityLow     = types.Severity{Str: "LOW", Priority: 3}
+	SeverityInfo    = types.Severity{Str: "INFO", Priority: 4}
 	SeverityUnknown = types.Severity{Str: "UNKNOWN", Priority: 99}
 
 	DetectedBlockTypeBackend  = types.DetectedBlockType{Value: "backend"}
@@ -13,3 +19,13 @@ var (
 	DetectedBlockTypeResource = types.DetectedBlockType{Value: "resource"}
 	DetectedBlockTypeData     = types.DetectedBlockType{Value: "data"}
 )
+
+// ParseSeverity returns the severity with the given case-insensitive name, e.g. "high" for SeverityHigh.
+func ParseSeverity(name string) (types.Severity, error) {
+	for _, severity := range []types.Severity{SeverityHigh, SeverityMedium, SeverityLow, SeverityInfo} {
+		if strings.EqualFold(name, severity.Str) {
+			return severity, nil
+		}
+	}
+	return types.Severity{}, fmt.Errorf("unknown severity %q (want high|medium|low|info)", name)
+}
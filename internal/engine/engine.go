@@ -2,6 +2,7 @@ package engine
 
 import (
 	"cmp"
+	"path/filepath"
 	"slices"
 	"strings"
 	"sync"
@@ -13,9 +14,20 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
+// RuleResolver returns the rules for a Terraform file below the given configuration files, which are ordered from the
+// outermost to the innermost directory. Files with the same configuration should share their rules. Only one rule per
+// ID gets finished, the registered one if any, so rules that report issues on Finish should be the same instance in
+// all rule sets.
+type RuleResolver func(file string, configFiles []string) ([]types.Rule, error)
+
 type Engine struct {
-	src   Source
-	rules []types.Rule
+	src              Source
+	rules            []types.Rule
+	ruleResolver     RuleResolver
+	appliedRules     []types.Rule
+	lintedFiles      []string
+	baseline         *processor.Baseline
+	baselineSnapshot *processor.Baseline
 }
 
 func New(src Source) *Engine {
@@ -32,24 +44,64 @@ func (e *Engine) RegisterMany(r []types.Rule) {
 	}
 }
 
+// UseRuleResolver applies the rules returned by resolve to each file instead of the registered rules.
+func (e *Engine) UseRuleResolver(resolve RuleResolver) {
+	e.ruleResolver = resolve
+}
+
+// Rules returns the rules applied by the last call to Run, which are the registered rules and those only enabled by
+// configuration files, one per rule ID.
+func (e *Engine) Rules() []types.Rule {
+	if e.appliedRules == nil {
+		return e.rules
+	}
+	return e.appliedRules
+}
+
+// LintedFiles returns the Terraform files found by the last call to Run.
+func (e *Engine) LintedFiles() []string {
+	return e.lintedFiles
+}
+
+// UseBaseline hides the issues recorded in baseline from the results of Run.
+func (e *Engine) UseBaseline(baseline *processor.Baseline) {
+	e.baseline = baseline
+}
+
+// BaselineSnapshot returns a baseline of all issues found by the last call to Run, including those hidden by the
+// baseline set with UseBaseline.
+func (e *Engine) BaselineSnapshot() *processor.Baseline {
+	return e.baselineSnapshot
+}
+
 func (e *Engine) Run(root string) ([]types.Issue, error) {
 	files, err := e.src.List(root)
 	if err != nil {
 		return nil, err
 	}
+	e.lintedFiles = files.TerraformFiles
+
+	rulesByFile, err := e.resolveRules(files)
+	if err != nil {
+		return nil, err
+	}
 
 	ignoreIssuesProcessor, err := processor.NewIgnoreIssuesProcessor(files.TFCoachIgnoreFiles)
 	if err != nil {
 		return nil, err
 	}
 
+	baselineProcessor := processor.NewBaselineProcessor(e.baseline, root)
+
 	issuesAfterApply := utils.FlatMapChan(files.TerraformFiles, func(path string, issuesChan chan<- types.Issue) {
-		e.processFile(path, issuesChan, ignoreIssuesProcessor)
+		e.processFile(path, rulesByFile[path], issuesChan, ignoreIssuesProcessor, baselineProcessor)
 	})
 
-	issuesAfterFinish := utils.FlatMap(e.rules, types.Rule.Finish)
+	issuesAfterFinish := utils.FlatMap(e.appliedRules, types.Rule.Finish)
 
 	issues := ignoreIssuesProcessor.ProcessIssues(slices.Concat(issuesAfterApply, issuesAfterFinish))
+	issues = baselineProcessor.ProcessIssues(issues)
+	e.baselineSnapshot = baselineProcessor.Snapshot()
 
 	// sort for deterministic output
 	slices.SortStableFunc(issues, func(a, b types.Issue) int {
@@ -71,7 +123,63 @@ func (e *Engine) Run(root string) ([]types.Issue, error) {
 	return issues, nil
 }
 
-func (e *Engine) processFile(path string, issuesChan chan<- types.Issue, postProcessor processor.IgnoreIssuesProcessor) {
+// resolveRules returns the rules for each Terraform file, which are the registered rules unless there is a rule
+// resolver.
+func (e *Engine) resolveRules(files *FileList) (map[string][]types.Rule, error) {
+	e.appliedRules = slices.Clone(e.rules)
+	rulesByFile := make(map[string][]types.Rule, len(files.TerraformFiles))
+	for _, path := range files.TerraformFiles {
+		if e.ruleResolver == nil {
+			rulesByFile[path] = e.rules
+			continue
+		}
+
+		rules, err := e.ruleResolver(path, configFilesFor(path, files.ConfigFiles))
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range rules {
+			if !slices.ContainsFunc(e.appliedRules, func(r types.Rule) bool { return r.ID() == rule.ID() }) {
+				e.appliedRules = append(e.appliedRules, rule)
+			}
+		}
+		rulesByFile[path] = rules
+	}
+	return rulesByFile, nil
+}
+
+// configFilesFor returns the configuration files in the directory of path and its parents, from the outermost to the
+// innermost directory.
+func configFilesFor(path string, configFiles []string) []string {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil
+	}
+	var found []string
+	for _, configFile := range configFiles {
+		absConfigFile, absErr := filepath.Abs(configFile)
+		if absErr != nil {
+			continue
+		}
+		rel, relErr := filepath.Rel(filepath.Dir(absConfigFile), filepath.Dir(absPath))
+		if relErr != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		found = append(found, absConfigFile)
+	}
+	slices.SortFunc(found, func(a, b string) int {
+		return cmp.Compare(len(filepath.Dir(a)), len(filepath.Dir(b)))
+	})
+	return found
+}
+
+func (e *Engine) processFile(
+	path string,
+	rules []types.Rule,
+	issuesChan chan<- types.Issue,
+	postProcessor processor.IgnoreIssuesProcessor,
+	baselineProcessor processor.BaselineProcessor,
+) {
 	bytes, err := e.src.ReadFile(path)
 	if err != nil {
 		issuesChan <- types.Issue{
@@ -95,11 +203,14 @@ func (e *Engine) processFile(path string, issuesChan chan<- types.Issue, postPro
 	fileProcessingGroup.Go(func() {
 		postProcessor.ScanFile(bytes, hclFile, path)
 	})
+	fileProcessingGroup.Go(func() {
+		baselineProcessor.ScanFile(hclFile, path)
+	})
 
 	applyOnFile := func(r types.Rule) []types.Issue {
 		return r.Apply(path, hclFile)
 	}
-	for _, issue := range utils.FlatMap(e.rules, applyOnFile) {
+	for _, issue := range utils.FlatMap(rules, applyOnFile) {
 		issuesChan <- issue
 	}
 
@@ -1,6 +1,10 @@
 package engine_test
 
 import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -126,3 +130,96 @@ resource "test" "test" {}`,
 		t.Fatalf("wanted 2, got %d", len(issues))
 	}
 }
+
+func TestEngine_LintedFiles(t *testing.T) {
+	src := testutil.MemSource{Files: map[string]string{"a.tf": `terraform {}`}}
+	e := engine.New(src)
+	_, err := e.Run(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := e.LintedFiles(); len(got) != 1 || got[0] != "a.tf" {
+		t.Fatalf("wanted [a.tf], got %v", got)
+	}
+}
+
+func TestEngine_WithRuleResolver(t *testing.T) {
+	src := testutil.MemSource{
+		Files: map[string]string{
+			"a.tf":               `terraform {}`,
+			"legacy/b.tf":        `terraform {}`,
+			"legacy/nested/c.tf": `terraform {}`,
+			"legacy/nested/d.tf": `terraform {}`,
+		},
+		ConfigFiles: []string{"legacy/nested/.tfcoach.yml", "legacy/.tfcoach.yml"},
+	}
+	e := engine.New(src)
+	e.RegisterMany([]types.Rule{
+		&testutil.AlwaysFlag{RuleID: "t.root", Message: "m"},
+		&testutil.FlagOnFinish{RuleID: "t.finish", Message: "m"},
+	})
+	configFilesByFile := make(map[string][]string)
+	ruleSets := make(map[int][]types.Rule)
+	e.UseRuleResolver(func(file string, configFiles []string) ([]types.Rule, error) {
+		configFilesByFile[file] = configFiles
+		if len(configFiles) == 0 {
+			return []types.Rule{&testutil.AlwaysFlag{RuleID: "t.root", Message: "m"}}, nil
+		}
+		if _, ok := ruleSets[len(configFiles)]; !ok {
+			ruleSets[len(configFiles)] = []types.Rule{
+				&testutil.AlwaysFlag{RuleID: "t.nested" + strconv.Itoa(len(configFiles)), Message: "m"},
+				&testutil.FlagOnFinish{RuleID: "t.finish", Message: "m"},
+			}
+		}
+		return ruleSets[len(configFiles)], nil
+	})
+	issues, err := e.Run(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ruleIDsByFile := make(map[string][]string)
+	for _, issue := range issues {
+		ruleIDsByFile[issue.File] = append(ruleIDsByFile[issue.File], issue.RuleID)
+	}
+	want := map[string][]string{
+		"a.tf":               {"t.root"},
+		"legacy/b.tf":        {"t.nested1"},
+		"legacy/nested/c.tf": {"t.nested2"},
+		"legacy/nested/d.tf": {"t.nested2"},
+		"somefile.tf":        {"t.finish"},
+	}
+	if !reflect.DeepEqual(ruleIDsByFile, want) {
+		t.Errorf("wanted rules by file %v, got %v", want, ruleIDsByFile)
+	}
+
+	if len(configFilesByFile["a.tf"]) != 0 {
+		t.Errorf("wanted no config files for a.tf, got %v", configFilesByFile["a.tf"])
+	}
+	if got := configFilesByFile["legacy/nested/c.tf"]; len(got) != 2 || !strings.HasSuffix(got[0], filepath.Join("legacy", ".tfcoach.yml")) {
+		t.Errorf("wanted both config files with the outermost first, got %v", got)
+	}
+
+	var ruleIDs []string
+	for _, rule := range e.Rules() {
+		ruleIDs = append(ruleIDs, rule.ID())
+	}
+	slices.Sort(ruleIDs)
+	if wantIDs := []string{"t.finish", "t.nested1", "t.nested2", "t.root"}; !slices.Equal(ruleIDs, wantIDs) {
+		t.Errorf("wanted rules %v, got %v", wantIDs, ruleIDs)
+	}
+}
+
+func TestEngine_WithFailingRuleResolver(t *testing.T) {
+	src := testutil.MemSource{
+		Files:       map[string]string{"legacy/a.tf": `terraform {}`},
+		ConfigFiles: []string{"legacy/.tfcoach.yml"},
+	}
+	e := engine.New(src)
+	e.UseRuleResolver(func(_ string, _ []string) ([]types.Rule, error) {
+		return nil, errors.New("invalid config")
+	})
+	if _, err := e.Run("."); err == nil {
+		t.Fatal("wanted the error of the resolver")
+	}
+}
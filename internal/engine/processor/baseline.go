@@ -0,0 +1,258 @@
+package processor
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Marcel2603/tfcoach/internal/types"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+const (
+	DefaultBaselineFileName = ".tfcoach-baseline.json"
+	baselineVersion         = 1
+)
+
+var numberPattern = regexp.MustCompile(`\d+`)
+
+// Baseline lists accepted issues. Issues are identified by a fingerprint of rule, file, block address and message,
+// so that they still match after lines have been added or removed above them.
+type Baseline struct {
+	Version int             `json:"version"`
+	Entries []BaselineEntry `json:"entries"`
+}
+
+type BaselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	RuleID      string `json:"rule_id"`
+	File        string `json:"file"`
+	Block       string `json:"block"`
+	Message     string `json:"message"`
+	// Count is the number of identical issues, e.g. the same rule broken twice in one block.
+	Count int `json:"count"`
+}
+
+type BaselineProcessor interface {
+	ProcessIssues(issues []types.Issue) []types.Issue
+	ScanFile(hclFile *hcl.File, path string)
+	// Snapshot returns a baseline of all issues passed to ProcessIssues, including those hidden by the baseline.
+	Snapshot() *Baseline
+}
+
+type addressedRange struct {
+	address  string
+	hclRange hcl.Range
+}
+
+type baselineProcessorImpl struct {
+	baseline *Baseline
+	root     string
+
+	mu           sync.Mutex
+	blocksByFile map[string][]addressedRange
+	snapshot     *Baseline
+}
+
+// NewBaselineProcessor creates a processor that hides the issues of baseline. A nil baseline hides nothing. Files are
+// identified by their path relative to root, the path that is linted.
+func NewBaselineProcessor(baseline *Baseline, root string) BaselineProcessor {
+	return &baselineProcessorImpl{
+		baseline:     baseline,
+		root:         root,
+		blocksByFile: make(map[string][]addressedRange),
+		snapshot:     &Baseline{Version: baselineVersion, Entries: []BaselineEntry{}},
+	}
+}
+
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+	var baseline Baseline
+	if err = json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	if baseline.Version != baselineVersion {
+		return nil, fmt.Errorf("unsupported baseline version %d in %s (want %d)", baseline.Version, path, baselineVersion)
+	}
+	return &baseline, nil
+}
+
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// IssueCount returns the number of issues covered by the baseline.
+func (b *Baseline) IssueCount() int {
+	count := 0
+	for _, entry := range b.Entries {
+		count += entry.Count
+	}
+	return count
+}
+
+// Prune drops the entries that are not part of current anymore. Entries of files that were not linted are kept, as
+// there is no way to tell whether they have been fixed. root is the path the files were linted with.
+func (b *Baseline) Prune(current *Baseline, root string, lintedFiles []string) *Baseline {
+	currentCounts := make(map[string]int, len(current.Entries))
+	for _, entry := range current.Entries {
+		currentCounts[entry.Fingerprint] = entry.Count
+	}
+	linted := make(map[string]bool, len(lintedFiles))
+	for _, file := range lintedFiles {
+		linted[normalizeBaselinePath(root, file)] = true
+	}
+
+	pruned := &Baseline{Version: baselineVersion, Entries: []BaselineEntry{}}
+	for _, entry := range b.Entries {
+		if entry.File == "" || linted[entry.File] {
+			entry.Count = min(entry.Count, currentCounts[entry.Fingerprint])
+		}
+		if entry.Count > 0 {
+			pruned.Entries = append(pruned.Entries, entry)
+		}
+	}
+	return pruned
+}
+
+func (p *baselineProcessorImpl) ScanFile(hclFile *hcl.File, path string) {
+	body, ok := hclFile.Body.(*hclsyntax.Body)
+	if !ok {
+		return
+	}
+
+	blocks := make([]addressedRange, 0, len(body.Blocks))
+	for _, block := range body.Blocks {
+		blocks = append(blocks, addressedRange{address: blockAddress(block), hclRange: block.Range()})
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.blocksByFile[path] = blocks
+}
+
+func (p *baselineProcessorImpl) ProcessIssues(issues []types.Issue) []types.Issue {
+	remaining := make(map[string]int)
+	if p.baseline != nil {
+		for _, entry := range p.baseline.Entries {
+			remaining[entry.Fingerprint] += entry.Count
+		}
+	}
+
+	// sequential on purpose: identical issues have to consume the entries of the baseline one by one
+	entries := make(map[string]*BaselineEntry)
+	var processedIssues []types.Issue
+	for _, issue := range issues {
+		entry := p.entryOf(issue)
+		if existing, ok := entries[entry.Fingerprint]; ok {
+			existing.Count++
+		} else {
+			entries[entry.Fingerprint] = &entry
+		}
+
+		if remaining[entry.Fingerprint] > 0 {
+			remaining[entry.Fingerprint]--
+			continue
+		}
+		processedIssues = append(processedIssues, issue)
+	}
+
+	p.snapshot = &Baseline{Version: baselineVersion, Entries: make([]BaselineEntry, 0, len(entries))}
+	for _, entry := range entries {
+		p.snapshot.Entries = append(p.snapshot.Entries, *entry)
+	}
+	slices.SortFunc(p.snapshot.Entries, func(a, b BaselineEntry) int {
+		return cmp.Or(
+			strings.Compare(a.File, b.File),
+			strings.Compare(a.Block, b.Block),
+			strings.Compare(a.RuleID, b.RuleID),
+			strings.Compare(a.Message, b.Message),
+		)
+	})
+	return processedIssues
+}
+
+func (p *baselineProcessorImpl) Snapshot() *Baseline {
+	return p.snapshot
+}
+
+func (p *baselineProcessorImpl) entryOf(issue types.Issue) BaselineEntry {
+	entry := BaselineEntry{
+		RuleID:  issue.RuleID,
+		File:    normalizeBaselinePath(p.root, issue.File),
+		Block:   p.blockAddressAt(issue.File, issue.Range.Start),
+		Message: normalizeMessage(issue.Message),
+		Count:   1,
+	}
+	hash := sha256.Sum256([]byte(strings.Join([]string{entry.RuleID, entry.File, entry.Block, entry.Message}, "\x00")))
+	entry.Fingerprint = hex.EncodeToString(hash[:])
+	return entry
+}
+
+func (p *baselineProcessorImpl) blockAddressAt(path string, pos hcl.Pos) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, block := range p.blocksByFile[path] {
+		if block.hclRange.ContainsPos(pos) {
+			return block.address
+		}
+	}
+	return ""
+}
+
+// blockAddress follows the Terraform address syntax where there is one, e.g. "aws_s3_bucket.logs" or "var.region".
+func blockAddress(block *hclsyntax.Block) string {
+	var parts []string
+	switch block.Type {
+	case "resource":
+		parts = block.Labels
+	case "data":
+		parts = append([]string{"data"}, block.Labels...)
+	case "variable":
+		parts = append([]string{"var"}, block.Labels...)
+	default:
+		parts = append([]string{block.Type}, block.Labels...)
+	}
+	return strings.Join(parts, ".")
+}
+
+// normalizeMessage makes messages independent of whitespace and numbers like counts or line references.
+func normalizeMessage(message string) string {
+	return numberPattern.ReplaceAllString(strings.Join(strings.Fields(message), " "), "#")
+}
+
+// normalizeBaselinePath makes path relative to root, so that e.g. "./infra" and "/home/me/infra" give the same
+// fingerprints. If root is a file, its name is used.
+func normalizeBaselinePath(root string, path string) string {
+	if path == "" {
+		return path
+	}
+	absRoot, rootErr := filepath.Abs(root)
+	absPath, pathErr := filepath.Abs(path)
+	if rootErr != nil || pathErr != nil {
+		return filepath.ToSlash(filepath.Clean(path))
+	}
+	relPath, err := filepath.Rel(absRoot, absPath)
+	switch {
+	case err != nil:
+		relPath = absPath
+	case relPath == ".":
+		relPath = filepath.Base(absPath)
+	}
+	return filepath.ToSlash(relPath)
+}
@@ -0,0 +1,165 @@
+package processor_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Marcel2603/tfcoach/internal/engine/processor"
+	"github.com/Marcel2603/tfcoach/internal/testutil"
+	"github.com/Marcel2603/tfcoach/internal/types"
+	"github.com/hashicorp/hcl/v2"
+)
+
+func issuesForEachBlock(t *testing.T, path string, content string, message string) []types.Issue {
+	t.Helper()
+	hclFile := testutil.ParseToHcl(t, path, content)
+	rule := testutil.AlwaysFlag{RuleID: "rule-a", Message: message}
+	return rule.Apply(path, hclFile)
+}
+
+func snapshotOf(t *testing.T, files map[string]string, message string) *processor.Baseline {
+	t.Helper()
+	proc := processor.NewBaselineProcessor(nil, ".")
+	var issues []types.Issue
+	for path, content := range files {
+		proc.ScanFile(testutil.ParseToHcl(t, path, content), path)
+		issues = append(issues, issuesForEachBlock(t, path, content, message)...)
+	}
+	if processed := proc.ProcessIssues(issues); len(processed) != len(issues) {
+		t.Fatalf("without baseline all issues have to be kept, got %d of %d", len(processed), len(issues))
+	}
+	return proc.Snapshot()
+}
+
+func TestBaselineProcessor_SurvivesLineShifts(t *testing.T) {
+	before := `resource "aws_s3_bucket" "a" {}
+variable "b" {}
+`
+	after := `# a new comment
+
+variable "b" {}
+
+resource "aws_s3_bucket" "a" {
+}
+
+data "aws_iam_policy" "c" {}
+`
+	baseline := snapshotOf(t, map[string]string{"main.tf": before}, "found 2 problems")
+
+	proc := processor.NewBaselineProcessor(baseline, ".")
+	proc.ScanFile(testutil.ParseToHcl(t, "main.tf", after), "main.tf")
+	processed := proc.ProcessIssues(issuesForEachBlock(t, "main.tf", after, "found  3 problems"))
+
+	if len(processed) != 1 {
+		t.Fatalf("want only the new issue, got %d issues: %v", len(processed), processed)
+	}
+	if processed[0].Range.Start.Line != 8 {
+		t.Errorf("want the issue of the new data block, got %v", processed[0])
+	}
+}
+
+func TestBaselineProcessor_SnapshotBlockAddresses(t *testing.T) {
+	content := `resource "aws_s3_bucket" "a" {}
+data "aws_iam_policy" "b" {}
+variable "c" {}
+module "d" {}
+locals {}
+`
+	baseline := snapshotOf(t, map[string]string{"./main.tf": content}, "m")
+
+	want := []string{"aws_s3_bucket.a", "data.aws_iam_policy.b", "locals", "module.d", "var.c"}
+	if len(baseline.Entries) != len(want) {
+		t.Fatalf("want %d entries, got %d", len(want), len(baseline.Entries))
+	}
+	for i, entry := range baseline.Entries {
+		if entry.Block != want[i] || entry.File != "main.tf" || entry.Count != 1 {
+			t.Errorf("entry %d = %+v, want block %s in main.tf", i, entry, want[i])
+		}
+	}
+}
+
+func TestBaselineProcessor_PathsRelativeToRoot(t *testing.T) {
+	absRoot := filepath.Join(t.TempDir(), "infra")
+	t.Chdir(filepath.Dir(absRoot))
+	content := `resource "x" "a" {}`
+
+	var fingerprints []string
+	for _, root := range []string{"./infra", absRoot} {
+		path := filepath.Join(root, "modules", "main.tf")
+		proc := processor.NewBaselineProcessor(nil, root)
+		proc.ScanFile(testutil.ParseToHcl(t, path, content), path)
+		proc.ProcessIssues(issuesForEachBlock(t, path, content, "m"))
+
+		entries := proc.Snapshot().Entries
+		if len(entries) != 1 || entries[0].File != "modules/main.tf" {
+			t.Fatalf("root %s: want one entry of modules/main.tf, got %+v", root, entries)
+		}
+		fingerprints = append(fingerprints, entries[0].Fingerprint)
+	}
+	if fingerprints[0] != fingerprints[1] {
+		t.Errorf("want the same fingerprint for relative and absolute roots, got %v", fingerprints)
+	}
+}
+
+func TestBaselineProcessor_CountsIdenticalIssues(t *testing.T) {
+	content := `resource "x" "a" {}`
+	issue := types.Issue{File: "main.tf", RuleID: "rule-a", Message: "m", Range: hcl.Range{Start: hcl.Pos{Line: 1}}}
+
+	proc := processor.NewBaselineProcessor(nil, ".")
+	proc.ScanFile(testutil.ParseToHcl(t, "main.tf", content), "main.tf")
+	proc.ProcessIssues([]types.Issue{issue, issue})
+	baseline := proc.Snapshot()
+	if len(baseline.Entries) != 1 || baseline.Entries[0].Count != 2 {
+		t.Fatalf("want one entry with count 2, got %+v", baseline.Entries)
+	}
+
+	proc = processor.NewBaselineProcessor(baseline, ".")
+	proc.ScanFile(testutil.ParseToHcl(t, "main.tf", content), "main.tf")
+	processed := proc.ProcessIssues([]types.Issue{issue, issue, issue})
+	if len(processed) != 1 {
+		t.Fatalf("want the third issue to be reported, got %d issues", len(processed))
+	}
+}
+
+func TestBaseline_Prune(t *testing.T) {
+	files := map[string]string{
+		"a.tf": `resource "x" "a" {}
+resource "x" "b" {}
+`,
+		"b.tf": `resource "x" "c" {}`,
+	}
+	baseline := snapshotOf(t, files, "m")
+	current := snapshotOf(t, map[string]string{"a.tf": `resource "x" "a" {}`}, "m")
+
+	pruned := baseline.Prune(current, ".", []string{"a.tf"})
+
+	var blocks []string
+	for _, entry := range pruned.Entries {
+		blocks = append(blocks, entry.Block)
+	}
+	// x.b was fixed, b.tf was not linted
+	if len(blocks) != 2 || blocks[0] != "x.a" || blocks[1] != "x.c" {
+		t.Fatalf("want entries x.a and x.c, got %v", blocks)
+	}
+}
+
+func TestBaseline_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), processor.DefaultBaselineFileName)
+	baseline := snapshotOf(t, map[string]string{"main.tf": `resource "x" "a" {}`}, "m")
+
+	if err := baseline.Save(path); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	loaded, err := processor.LoadBaseline(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(loaded.Entries) != 1 || loaded.Entries[0] != baseline.Entries[0] {
+		t.Fatalf("got %+v, want %+v", loaded.Entries, baseline.Entries)
+	}
+
+	createFile(t, path, `{"version": 2, "entries": []}`)
+	if _, err = processor.LoadBaseline(path); err == nil {
+		t.Fatalf("Expected error for unsupported version, got none")
+	}
+}
@@ -19,10 +19,15 @@ type Source interface {
 type FileList struct {
 	TerraformFiles     []string
 	TFCoachIgnoreFiles []string
+	// ConfigFiles holds at most one configuration file per directory, see FileSystem.ConfigFileNames.
+	ConfigFiles []string
 }
 
 type FileSystem struct {
 	SkipDirs []string
+	// ConfigFileNames are the names of configuration files, in the order they are looked up in a directory. No
+	// configuration files are listed if empty.
+	ConfigFileNames []string
 }
 
 func (f FileSystem) List(root string) (*FileList, error) {
@@ -34,6 +39,7 @@ func (f FileSystem) List(root string) (*FileList, error) {
 	}
 	var foundTerraformFiles []string
 	var foundIgnoreFiles []string
+	var foundConfigFiles []string
 	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
@@ -42,6 +48,7 @@ func (f FileSystem) List(root string) (*FileList, error) {
 			if _, ok := skip[d.Name()]; ok {
 				return filepath.SkipDir
 			}
+			foundConfigFiles = append(foundConfigFiles, f.configFileIn(p)...)
 			return nil
 		}
 		if strings.HasSuffix(p, ".tf") {
@@ -56,7 +63,7 @@ func (f FileSystem) List(root string) (*FileList, error) {
 		return nil, err
 	}
 
-	// Also search parent directories of root for .tfcoachignore files, so that
+	// Also search parent directories of root for .tfcoachignore and configuration files, so that
 	// running `tfcoach lint subdir` still respects ignore files at the repo root.
 	absRoot, err := filepath.Abs(root)
 	if err != nil {
@@ -67,14 +74,28 @@ func (f FileSystem) List(root string) (*FileList, error) {
 		if _, err := os.Stat(p); err == nil {
 			foundIgnoreFiles = append(foundIgnoreFiles, p)
 		}
+		foundConfigFiles = append(foundConfigFiles, f.configFileIn(d)...)
 	}
 
 	sort.Strings(foundTerraformFiles) // deterministic order
 	sort.Strings(foundIgnoreFiles)
+	sort.Strings(foundConfigFiles)
 	return &FileList{
 		TerraformFiles:     foundTerraformFiles,
 		TFCoachIgnoreFiles: foundIgnoreFiles,
+		ConfigFiles:        foundConfigFiles,
 	}, nil
 }
 
+// configFileIn returns the first configuration file found in dir, if any.
+func (f FileSystem) configFileIn(dir string) []string {
+	for _, name := range f.ConfigFileNames {
+		p := filepath.Join(dir, name)
+		if fi, err := os.Stat(p); err == nil && fi.Mode().IsRegular() {
+			return []string{p}
+		}
+	}
+	return nil
+}
+
 func (FileSystem) ReadFile(path string) ([]byte, error) { return os.ReadFile(path) }
@@ -3,6 +3,7 @@ package engine_test
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 
 	"github.com/Marcel2603/tfcoach/internal/engine"
@@ -139,6 +140,39 @@ func TestFileSystem_List_IgnoreFiles(t *testing.T) {
 	}
 }
 
+func TestFileSystem_List_ConfigFiles(t *testing.T) {
+	root := t.TempDir()
+	target := filepath.Join(root, "repo")
+	createFile(t, filepath.Join(root, ".tfcoach.yml"), "")
+	createFile(t, filepath.Join(target, "main.tf"), "")
+	createFile(t, filepath.Join(target, "legacy", ".tfcoach.json"), "")
+	createFile(t, filepath.Join(target, "legacy", ".tfcoach.yml"), "")
+	createFile(t, filepath.Join(target, "modules", ".tfcoach", "config.yml"), "") // directory, not a config file
+	createFile(t, filepath.Join(target, "vendor", ".tfcoach.yml"), "")
+
+	fs := engine.FileSystem{SkipDirs: []string{"vendor"}, ConfigFileNames: []string{".tfcoach.yml", ".tfcoach.json", ".tfcoach"}}
+	got, err := fs.List(target)
+	if err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+
+	want := []string{
+		filepath.Join(root, ".tfcoach.yml"),
+		filepath.Join(target, "legacy", ".tfcoach.yml"),
+	}
+	if !slices.Equal(got.ConfigFiles, want) {
+		t.Errorf("ConfigFiles = %v, want %v", got.ConfigFiles, want)
+	}
+
+	got, err = engine.FileSystem{}.List(target)
+	if err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+	if len(got.ConfigFiles) != 0 {
+		t.Errorf("ConfigFiles = %v, want none without ConfigFileNames", got.ConfigFiles)
+	}
+}
+
 func TestFileSystem_ReadFile(t *testing.T) {
 	root := t.TempDir()
 	path := filepath.Join(root, "main.tf")
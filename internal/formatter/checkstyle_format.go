@@ -0,0 +1,82 @@
+package formatter
+
+import (
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/Marcel2603/tfcoach/internal/constants"
+	"github.com/Marcel2603/tfcoach/internal/types"
+)
+
+const checkstyleVersion = "4.3"
+
+type checkstyleOutput struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+func writeCheckstyle(issues []issueOutput, w io.Writer) error {
+	issuesGroupedByFile := make(map[string][]issueOutput)
+	for _, issue := range issues {
+		issuesGroupedByFile[issue.File] = append(issuesGroupedByFile[issue.File], issue)
+	}
+
+	output := checkstyleOutput{Version: checkstyleVersion}
+	for _, fileName := range slices.Sorted(maps.Keys(issuesGroupedByFile)) {
+		issuesInFile := issuesGroupedByFile[fileName]
+		slices.SortStableFunc(issuesInFile, func(a, b issueOutput) int {
+			if a.Line != b.Line {
+				return cmp.Compare(a.Line, b.Line)
+			}
+			return cmp.Compare(a.Column, b.Column)
+		})
+
+		file := checkstyleFile{Name: fileName}
+		for _, issue := range issuesInFile {
+			file.Errors = append(file.Errors, checkstyleError{
+				Line:     issue.Line,
+				Column:   issue.Column,
+				Severity: checkstyleSeverity(issue.Severity),
+				Message:  issue.Message,
+				Source:   issue.RuleID,
+			})
+		}
+		output.Files = append(output.Files, file)
+	}
+
+	outputAsStr, err := xml.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s%s\n", xml.Header, outputAsStr)
+	return err
+}
+
+func checkstyleSeverity(severity types.Severity) string {
+	switch severity {
+	case constants.SeverityHigh:
+		return "error"
+	case constants.SeverityLow, constants.SeverityInfo:
+		return "info"
+	default:
+		return "warning"
+	}
+}
@@ -27,5 +27,5 @@ func writeTextIssuesCompact(issues []issueOutput, w io.Writer) {
 }
 
 func writeTextSummaryCompact(issues []issueOutput, w io.Writer) {
-	_, _ = fmt.Fprintf(w, "Summary: %d issue%s\n", len(issues), condPlural(len(issues)))
+	_, _ = fmt.Fprintf(w, "Summary: %d issue%s\n", len(issues), CondPlural(len(issues)))
 }
@@ -18,9 +18,9 @@ func writeEducational(issues []issueOutput, allowEmojis bool, w io.Writer) error
 		w,
 		"Summary: %s rule%s broken (%s issue%s total)\n",
 		boldFont.Sprint(len(issuesGroupedByRuleID)),
-		condPlural(len(issuesGroupedByRuleID)),
+		CondPlural(len(issuesGroupedByRuleID)),
 		boldFont.Sprint(len(issues)),
-		condPlural(len(issues)),
+		CondPlural(len(issues)),
 	)
 	if err != nil {
 		return err
@@ -45,12 +45,7 @@ func writeEducational(issues []issueOutput, allowEmojis bool, w io.Writer) error
 
 		padding := strings.Repeat("─", longestRuleTitle-len(ruleMeta.Title)-len(ruleMeta.Severity.String()))
 
-		var docsURL string
-		if ruleMeta.DocsURI == "about:blank" {
-			docsURL = "about:blank"
-		} else {
-			docsURL = fmt.Sprintf(ruleDocsFormat, ruleMeta.DocsURI)
-		}
+		docsURL := ruleDocsURL(ruleMeta)
 
 		_, err = fmt.Fprintf(
 			w,
@@ -83,6 +78,10 @@ func writeEducational(issues []issueOutput, allowEmojis bool, w io.Writer) error
 			if err != nil {
 				return err
 			}
+			err = writeSnippet(issue, "    ", w)
+			if err != nil {
+				return err
+			}
 		}
 		_, err = fmt.Fprintln(w)
 		if err != nil {
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"slices"
+	"time"
 
 	"github.com/Marcel2603/tfcoach/internal/constants"
 	"github.com/Marcel2603/tfcoach/internal/types"
@@ -11,60 +13,153 @@ import (
 	"github.com/fatih/color"
 )
 
-const ruleDocsFormat = "https://marcel2603.github.io/tfcoach/rules/%s"
+const (
+	ruleDocsFormat = "https://marcel2603.github.io/tfcoach/rules/%s"
+	// reportSchemaVersion has to be raised whenever the JSON report changes in a way that breaks its consumers, see
+	// docs/pages/schemas for the published schemas
+	reportSchemaVersion = 1
+)
 
 var (
 	boldFont  = color.New(color.Bold)
 	greyColor = color.RGB(90, 90, 90)
+
+	humanReadableFormats = []string{"compact", "pretty", "educational"}
 )
 
 type issueOutput struct {
-	File     string         `json:"file"`
-	Line     int            `json:"line"`
-	Column   int            `json:"column"`
-	Message  string         `json:"message"`
-	RuleID   string         `json:"rule_id"`
-	Severity types.Severity `json:"severity"`
-	Category string         `json:"category"`
-	DocsURL  string         `json:"docs_url"`
+	File      string         `json:"file"`
+	Line      int            `json:"line"`
+	Column    int            `json:"column"`
+	EndLine   int            `json:"end_line"`
+	EndColumn int            `json:"end_column"`
+	Message   string         `json:"message"`
+	RuleID    string         `json:"rule_id"`
+	Severity  types.Severity `json:"severity"`
+	Category  string         `json:"category"`
+	DocsURL   string         `json:"docs_url"`
+	Snippet   *snippetOutput `json:"snippet,omitempty"`
+	Details   map[string]any `json:"details,omitempty"`
+}
+
+// Options holds settings shared by all output formats.
+type Options struct {
+	AllowEmojis bool
+	// TemplatePath is the text/template file rendered by the "template" format.
+	TemplatePath string
+	// ReadSource gives access to the linted files, so that issues can carry a snippet of the offending source. Without
+	// it, issues come without snippets.
+	ReadSource func(path string) ([]byte, error)
+	// Run describes the lint run, it is stored in the JSON report if set.
+	Run *RunInfo
+}
+
+// RunInfo describes the lint run that produced the issues.
+type RunInfo struct {
+	BuildVersion string
+	BuildCommit  string
+	Root         string
+	// Rules are the rules that were executed, all other known rules count as disabled.
+	Rules     []types.Rule
+	Config    any
+	FileCount int
+	StartedAt time.Time
+	Duration  time.Duration
 }
 
-type jsonOutput struct {
-	IssueCount int           `json:"issue_count"`
-	Issues     []issueOutput `json:"issues"`
+// IsHumanReadable reports whether a format is meant to be read by people rather than parsed by tools.
+func IsHumanReadable(outputFormat string) bool {
+	return slices.Contains(humanReadableFormats, outputFormat)
 }
 
-func WriteResults(issues []types.Issue, w io.Writer, outputFormat string, allowEmojis bool) error {
-	preparedIssues := toIssueOutputs(issues)
-	return writeResultsFromPrepared(preparedIssues, w, outputFormat, allowEmojis)
+func WriteResults(issues []types.Issue, lintedFiles []string, w io.Writer, outputFormat string, options Options) error {
+	var sources *sourceCache
+	if options.ReadSource != nil {
+		sources = newSourceCache(options.ReadSource)
+	}
+	report := newJSONOutput(toIssueOutputs(issues, sources), options.Run)
+	return writeResultsFromPrepared(report, lintedFiles, w, outputFormat, options)
 }
 
-func ReformatResults(srcReport []byte, w io.Writer, outputFormat string, allowEmojis bool) error {
+// ReformatResults converts a JSON report into another format. Reports written before the schema was versioned are
+// read as well, they simply lack the run metadata.
+func ReformatResults(srcReport []byte, w io.Writer, outputFormat string, options Options) error {
 	var report jsonOutput
 	err := json.Unmarshal(srcReport, &report)
 	if err != nil {
 		return err
 	}
-	return writeResultsFromPrepared(report.Issues, w, outputFormat, allowEmojis)
+	if report.SchemaVersion > reportSchemaVersion {
+		return fmt.Errorf(
+			"unsupported report schema version %d (this tfcoach supports up to %d)",
+			report.SchemaVersion,
+			reportSchemaVersion,
+		)
+	}
+	report.SchemaVersion = reportSchemaVersion
+	report.IssueCount = len(report.Issues)
+	// the report does not know about files without issues
+	return writeResultsFromPrepared(report, nil, w, outputFormat, options)
 }
 
-func writeResultsFromPrepared(issues []issueOutput, w io.Writer, outputFormat string, allowEmojis bool) error {
+func writeResultsFromPrepared(report jsonOutput, lintedFiles []string, w io.Writer, outputFormat string, options Options) error {
+	issues := report.Issues
 	switch outputFormat {
 	case "compact":
 		writeTextIssuesCompact(issues, w)
 		writeTextSummaryCompact(issues, w)
 	case "json":
-		err := writeJSON(issues, w)
+		err := writeJSON(report, w)
 		if err != nil {
 			return err
 		}
 	case "pretty":
-		err := writePretty(issues, allowEmojis, w)
+		err := writePretty(issues, options.AllowEmojis, w)
 		if err != nil {
 			return err
 		}
 	case "educational":
-		err := writeEducational(issues, allowEmojis, w)
+		err := writeEducational(issues, options.AllowEmojis, w)
+		if err != nil {
+			return err
+		}
+	case "sarif":
+		err := writeSARIF(issues, w)
+		if err != nil {
+			return err
+		}
+	case "junit":
+		err := writeJUnit(issues, lintedFiles, w)
+		if err != nil {
+			return err
+		}
+	case "gitlab":
+		err := writeGitLab(issues, w)
+		if err != nil {
+			return err
+		}
+	case "github":
+		err := writeGitHub(issues, w)
+		if err != nil {
+			return err
+		}
+	case "checkstyle":
+		err := writeCheckstyle(issues, w)
+		if err != nil {
+			return err
+		}
+	case "html":
+		err := writeHTML(issues, options.ReadSource, w)
+		if err != nil {
+			return err
+		}
+	case "markdown":
+		err := writeMarkdown(issues, options.AllowEmojis, w)
+		if err != nil {
+			return err
+		}
+	case "template":
+		err := writeTemplate(report, options.TemplatePath, w)
 		if err != nil {
 			return err
 		}
@@ -74,38 +169,56 @@ func writeResultsFromPrepared(issues []issueOutput, w io.Writer, outputFormat st
 	return nil
 }
 
-func toIssueOutputs(issues []types.Issue) []issueOutput {
+func toIssueOutputs(issues []types.Issue, sources *sourceCache) []issueOutput {
 	var result []issueOutput
+	// configuring a rule compiles its options, so each rule is only looked up once
+	metas := make(map[string]*types.RuleMeta)
 
 	for _, issue := range issues {
-		rule, err := core.FindByID(issue.RuleID)
-		var severity types.Severity
-		var docsURL string
-		if err != nil {
-			severity = constants.SeverityUnknown
-			docsURL = "about:blank"
-		} else {
-			rulesMeta := rule.META()
-			severity = rulesMeta.Severity
-			docsURL = fmt.Sprintf(ruleDocsFormat, rulesMeta.DocsURI)
+		meta, found := metas[issue.RuleID]
+		if !found {
+			if rule, err := core.FindByID(issue.RuleID); err == nil {
+				ruleMeta := rule.META()
+				meta = &ruleMeta
+			}
+			metas[issue.RuleID] = meta
+		}
+		severity := constants.SeverityUnknown
+		docsURL := "about:blank"
+		if meta != nil {
+			severity = meta.Severity
+			docsURL = ruleDocsURL(*meta)
 		}
 
-		result = append(result, issueOutput{
-			File:     issue.File,
-			Line:     issue.Range.Start.Line,
-			Column:   issue.Range.Start.Column,
-			Message:  issue.Message,
-			RuleID:   issue.RuleID,
-			Severity: severity,
+		output := issueOutput{
+			File:      issue.File,
+			Line:      issue.Range.Start.Line,
+			Column:    issue.Range.Start.Column,
+			EndLine:   issue.Range.End.Line,
+			EndColumn: issue.Range.End.Column,
+			Message:   issue.Message,
+			RuleID:    issue.RuleID,
+			Severity:  severity,
 			//Category: "?",  // TODO later: implement rule category
 			DocsURL: docsURL,
-		})
+			Details: issue.Details,
+		}
+		output.Snippet = sources.captureSnippet(output)
+		result = append(result, output)
 	}
 
 	return result
 }
 
-func condPlural(n int) string {
+func ruleDocsURL(ruleMeta types.RuleMeta) string {
+	if ruleMeta.DocsURI == "about:blank" {
+		return ruleMeta.DocsURI
+	}
+	return fmt.Sprintf(ruleDocsFormat, ruleMeta.DocsURI)
+}
+
+// CondPlural returns the plural suffix for n things, e.g. "issue" + CondPlural(2).
+func CondPlural(n int) string {
 	if n == 1 {
 		return ""
 	}
@@ -3,11 +3,16 @@ package formatter_test
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/Marcel2603/tfcoach/internal/formatter"
 	"github.com/Marcel2603/tfcoach/internal/types"
+	"github.com/Marcel2603/tfcoach/rules/core"
 	"github.com/hashicorp/hcl/v2"
 )
 
@@ -157,12 +162,15 @@ U a.tf:4:7: m1 [core.something_something]
 Summary: 4 issues
 `
 	issues3Json = `{
+  "schema_version": 1,
   "issue_count": 4,
   "issues": [
     {
       "file": "a.tf",
       "line": 4,
       "column": 7,
+      "end_line": 4,
+      "end_column": 7,
       "message": "m1",
       "rule_id": "core.something_something",
       "severity": {
@@ -176,6 +184,8 @@ Summary: 4 issues
       "file": "b.tf",
       "line": 9,
       "column": 2,
+      "end_line": 9,
+      "end_column": 2,
       "message": "m2",
       "rule_id": "core.naming_convention",
       "severity": {
@@ -189,6 +199,8 @@ Summary: 4 issues
       "file": "a.tf",
       "line": 10,
       "column": 2,
+      "end_line": 10,
+      "end_column": 2,
       "message": "m3",
       "rule_id": "core.naming_convention",
       "severity": {
@@ -202,6 +214,8 @@ Summary: 4 issues
       "file": "a.tf",
       "line": 2,
       "column": 1,
+      "end_line": 2,
+      "end_column": 1,
       "message": "m4",
       "rule_id": "core.file_naming",
       "severity": {
@@ -226,7 +240,7 @@ func rng(file string, line0, col int) hcl.Range {
 
 func TestWriteResults_CompactSingle(t *testing.T) {
 	var buf bytes.Buffer
-	err := formatter.WriteResults(issues1, &buf, "compact", true)
+	err := formatter.WriteResults(issues1, nil, &buf, "compact", formatter.Options{AllowEmojis: true})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v, want none", err)
 	}
@@ -242,7 +256,7 @@ Summary: 1 issue
 
 func TestWriteResults_CompactMultiple(t *testing.T) {
 	var buf bytes.Buffer
-	err := formatter.WriteResults(issues2, &buf, "compact", true)
+	err := formatter.WriteResults(issues2, nil, &buf, "compact", formatter.Options{AllowEmojis: true})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v, want none", err)
 	}
@@ -259,18 +273,21 @@ Summary: 2 issues
 
 func TestWriteResults_JsonSingle(t *testing.T) {
 	var buf bytes.Buffer
-	err := formatter.WriteResults(issues1, &buf, "json", true)
+	err := formatter.WriteResults(issues1, nil, &buf, "json", formatter.Options{AllowEmojis: true})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v, want none", err)
 	}
 
 	want := `{
+  "schema_version": 1,
   "issue_count": 1,
   "issues": [
 	{
 	  "file": "main.tf",
 	  "line": 0,
 	  "column": 1,
+	  "end_line": 0,
+	  "end_column": 1,
 	  "message": "Block \"a\" should be inside of \"b.tf\"",
 	  "rule_id": "core.file_naming",
 	  "severity": {
@@ -302,18 +319,21 @@ func TestWriteResults_JsonSingle(t *testing.T) {
 
 func TestWriteResults_JsonMultiple(t *testing.T) {
 	var buf bytes.Buffer
-	err := formatter.WriteResults(issues2, &buf, "json", true)
+	err := formatter.WriteResults(issues2, nil, &buf, "json", formatter.Options{AllowEmojis: true})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v, want none", err)
 	}
 
 	want := `{
+  "schema_version": 1,
   "issue_count": 2,
   "issues": [
 	{
 	  "file": "a.tf",
 	  "line": 4,
 	  "column": 7,
+	  "end_line": 4,
+	  "end_column": 7,
 	  "message": "m1",
 	  "rule_id": "core.something_something",
       "severity": {
@@ -327,6 +347,8 @@ func TestWriteResults_JsonMultiple(t *testing.T) {
 	  "file": "b.tf",
 	  "line": 9,
 	  "column": 2,
+	  "end_line": 9,
+	  "end_column": 2,
 	  "message": "m2",
 	  "rule_id": "core.naming_convention",
 	  "severity": {
@@ -357,7 +379,7 @@ func TestWriteResults_JsonMultiple(t *testing.T) {
 
 func TestWriteResults_PrettySingle(t *testing.T) {
 	var buf bytes.Buffer
-	err := formatter.WriteResults(issues1, &buf, "pretty", true)
+	err := formatter.WriteResults(issues1, nil, &buf, "pretty", formatter.Options{AllowEmojis: true})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v, want none", err)
 	}
@@ -379,7 +401,7 @@ func TestWriteResults_PrettySingle(t *testing.T) {
 
 func TestWriteResults_PrettyMultiple(t *testing.T) {
 	var buf bytes.Buffer
-	err := formatter.WriteResults(issues2, &buf, "pretty", true)
+	err := formatter.WriteResults(issues2, nil, &buf, "pretty", formatter.Options{AllowEmojis: true})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v, want none", err)
 	}
@@ -407,7 +429,7 @@ func TestWriteResults_PrettyMultiple(t *testing.T) {
 
 func TestWriteResults_PrettySorting(t *testing.T) {
 	var buf bytes.Buffer
-	err := formatter.WriteResults(issues3, &buf, "pretty", true)
+	err := formatter.WriteResults(issues3, nil, &buf, "pretty", formatter.Options{AllowEmojis: true})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v, want none", err)
 	}
@@ -419,7 +441,7 @@ func TestWriteResults_PrettySorting(t *testing.T) {
 
 func TestWriteResults_PrettyNoEmojis(t *testing.T) {
 	var buf bytes.Buffer
-	err := formatter.WriteResults(issues3, &buf, "pretty", false)
+	err := formatter.WriteResults(issues3, nil, &buf, "pretty", formatter.Options{AllowEmojis: false})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v, want none", err)
 	}
@@ -431,7 +453,7 @@ func TestWriteResults_PrettyNoEmojis(t *testing.T) {
 
 func TestWriteResults_EducationalSingle(t *testing.T) {
 	var buf bytes.Buffer
-	err := formatter.WriteResults(issues1, &buf, "educational", true)
+	err := formatter.WriteResults(issues1, nil, &buf, "educational", formatter.Options{AllowEmojis: true})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v, want none", err)
 	}
@@ -457,7 +479,7 @@ func TestWriteResults_EducationalSingle(t *testing.T) {
 
 func TestWriteResults_EducationalMultiple(t *testing.T) {
 	var buf bytes.Buffer
-	err := formatter.WriteResults(issues2, &buf, "educational", true)
+	err := formatter.WriteResults(issues2, nil, &buf, "educational", formatter.Options{AllowEmojis: true})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v, want none", err)
 	}
@@ -494,7 +516,7 @@ func TestWriteResults_EducationalMultiple(t *testing.T) {
 
 func TestWriteResults_EducationalSorting(t *testing.T) {
 	var buf bytes.Buffer
-	err := formatter.WriteResults(issues3, &buf, "educational", true)
+	err := formatter.WriteResults(issues3, nil, &buf, "educational", formatter.Options{AllowEmojis: true})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v, want none", err)
 	}
@@ -506,7 +528,7 @@ func TestWriteResults_EducationalSorting(t *testing.T) {
 
 func TestWriteResults_EducationalNoEmojis(t *testing.T) {
 	var buf bytes.Buffer
-	err := formatter.WriteResults(issues3, &buf, "educational", false)
+	err := formatter.WriteResults(issues3, nil, &buf, "educational", formatter.Options{AllowEmojis: false})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v, want none", err)
 	}
@@ -518,7 +540,7 @@ func TestWriteResults_EducationalNoEmojis(t *testing.T) {
 
 func TestWriteResults_UnknownFormat(t *testing.T) {
 	var buf bytes.Buffer
-	err := formatter.WriteResults(issues1, &buf, "abcd", true)
+	err := formatter.WriteResults(issues1, nil, &buf, "abcd", formatter.Options{AllowEmojis: true})
 	if err == nil {
 		t.Fatalf("Expected error, got none")
 	}
@@ -531,7 +553,7 @@ func TestWriteResults_UnknownFormat(t *testing.T) {
 
 func TestReformatResults_Pretty(t *testing.T) {
 	var buf bytes.Buffer
-	err := formatter.ReformatResults([]byte(issues3Json), &buf, "pretty", true)
+	err := formatter.ReformatResults([]byte(issues3Json), &buf, "pretty", formatter.Options{AllowEmojis: true})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v, want none", err)
 	}
@@ -543,7 +565,7 @@ func TestReformatResults_Pretty(t *testing.T) {
 
 func TestReformatResults_PrettyNoEmojis(t *testing.T) {
 	var buf bytes.Buffer
-	err := formatter.ReformatResults([]byte(issues3Json), &buf, "pretty", false)
+	err := formatter.ReformatResults([]byte(issues3Json), &buf, "pretty", formatter.Options{AllowEmojis: false})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v, want none", err)
 	}
@@ -555,7 +577,7 @@ func TestReformatResults_PrettyNoEmojis(t *testing.T) {
 
 func TestReformatResults_Educational(t *testing.T) {
 	var buf bytes.Buffer
-	err := formatter.ReformatResults([]byte(issues3Json), &buf, "educational", true)
+	err := formatter.ReformatResults([]byte(issues3Json), &buf, "educational", formatter.Options{AllowEmojis: true})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v, want none", err)
 	}
@@ -567,7 +589,7 @@ func TestReformatResults_Educational(t *testing.T) {
 
 func TestReformatResults_EducationalNoEmojis(t *testing.T) {
 	var buf bytes.Buffer
-	err := formatter.ReformatResults([]byte(issues3Json), &buf, "educational", false)
+	err := formatter.ReformatResults([]byte(issues3Json), &buf, "educational", formatter.Options{AllowEmojis: false})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v, want none", err)
 	}
@@ -579,7 +601,7 @@ func TestReformatResults_EducationalNoEmojis(t *testing.T) {
 
 func TestReformatResults_Compact(t *testing.T) {
 	var buf bytes.Buffer
-	err := formatter.ReformatResults([]byte(issues3Json), &buf, "compact", true)
+	err := formatter.ReformatResults([]byte(issues3Json), &buf, "compact", formatter.Options{AllowEmojis: true})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v, want none", err)
 	}
@@ -591,7 +613,7 @@ func TestReformatResults_Compact(t *testing.T) {
 
 func TestReformatResults_Json(t *testing.T) {
 	var buf bytes.Buffer
-	err := formatter.ReformatResults([]byte(issues3Json), &buf, "json", true)
+	err := formatter.ReformatResults([]byte(issues3Json), &buf, "json", formatter.Options{AllowEmojis: true})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v, want none", err)
 	}
@@ -601,9 +623,34 @@ func TestReformatResults_Json(t *testing.T) {
 	}
 }
 
+func TestReformatResults_UnversionedReport(t *testing.T) {
+	unversionedReport := strings.Replace(issues3Json, "  \"schema_version\": 1,\n", "", 1)
+	if unversionedReport == issues3Json {
+		t.Fatalf("Setup error: fixture has no schema_version")
+	}
+
+	var buf bytes.Buffer
+	err := formatter.ReformatResults([]byte(unversionedReport), &buf, "json", formatter.Options{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+
+	if got := buf.String(); got != issues3Json {
+		t.Fatalf("mismatch:\n got:\n%s\nwant:\n%s", got, issues3Json)
+	}
+}
+
+func TestReformatResults_UnsupportedSchemaVersion(t *testing.T) {
+	var buf bytes.Buffer
+	err := formatter.ReformatResults([]byte(`{"schema_version": 99, "issues": []}`), &buf, "json", formatter.Options{})
+	if err == nil {
+		t.Fatalf("Expected error, got none")
+	}
+}
+
 func TestReformatResults_UnknownFormat(t *testing.T) {
 	var buf bytes.Buffer
-	err := formatter.ReformatResults([]byte(issues3Json), &buf, "xyz", true)
+	err := formatter.ReformatResults([]byte(issues3Json), &buf, "xyz", formatter.Options{AllowEmojis: true})
 	if err == nil {
 		t.Fatalf("Expected error, got none")
 	}
@@ -613,3 +660,791 @@ func TestReformatResults_UnknownFormat(t *testing.T) {
 		t.Fatalf("mismatch:\n got: %q\nwant: %q", err, want)
 	}
 }
+
+func TestWriteResults_Sarif(t *testing.T) {
+	var buf bytes.Buffer
+	err := formatter.WriteResults(issues2, nil, &buf, "sarif", formatter.Options{AllowEmojis: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+
+	var got struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID      string `json:"id"`
+						HelpURI string `json:"helpUri"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []map[string]interface{} `json:"results"`
+		} `json:"runs"`
+	}
+	if err = json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Unexpected unmarshalling error: %v, want none", err)
+	}
+
+	if got.Version != "2.1.0" {
+		t.Fatalf("version = %q, want %q", got.Version, "2.1.0")
+	}
+	if len(got.Runs) != 1 {
+		t.Fatalf("wanted 1 run, got %d", len(got.Runs))
+	}
+	run := got.Runs[0]
+	if run.Tool.Driver.Name != "tfcoach" {
+		t.Fatalf("driver name = %q, want %q", run.Tool.Driver.Name, "tfcoach")
+	}
+	if len(run.Tool.Driver.Rules) != len(core.All()) {
+		t.Fatalf("wanted %d rules, got %d", len(core.All()), len(run.Tool.Driver.Rules))
+	}
+
+	wantResults := `[
+  {
+    "ruleId": "core.something_something",
+    "level": "none",
+    "message": {"text": "m1"},
+    "locations": [
+      {
+        "physicalLocation": {
+          "artifactLocation": {"uri": "a.tf"},
+          "region": {"startLine": 4, "startColumn": 7, "endLine": 4, "endColumn": 7}
+        }
+      }
+    ]
+  },
+  {
+    "ruleId": "core.naming_convention",
+    "ruleIndex": 0,
+    "level": "error",
+    "message": {"text": "m2"},
+    "locations": [
+      {
+        "physicalLocation": {
+          "artifactLocation": {"uri": "b.tf"},
+          "region": {"startLine": 9, "startColumn": 2, "endLine": 9, "endColumn": 2}
+        }
+      }
+    ]
+  }
+]`
+	var wantJ []map[string]interface{}
+	if err = json.Unmarshal([]byte(wantResults), &wantJ); err != nil {
+		t.Fatalf("Unexpected unmarshalling error in test setup: %v, want none", err)
+	}
+	if !reflect.DeepEqual(run.Results, wantJ) {
+		t.Fatalf("SARIF results mismatch:\n got:\n%v\nwant:\n%v", run.Results, wantJ)
+	}
+}
+
+func TestReformatResults_Sarif(t *testing.T) {
+	var buf bytes.Buffer
+	err := formatter.ReformatResults([]byte(issues3Json), &buf, "sarif", formatter.Options{AllowEmojis: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+
+	var got map[string]interface{}
+	if err = json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Unexpected unmarshalling error: %v, want none", err)
+	}
+	if got["version"] != "2.1.0" {
+		t.Fatalf("version = %v, want %q", got["version"], "2.1.0")
+	}
+}
+
+func TestWriteResults_JUnit(t *testing.T) {
+	var buf bytes.Buffer
+	err := formatter.WriteResults(issues3, []string{"a.tf", "b.tf", "c.tf"}, &buf, "junit", formatter.Options{AllowEmojis: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="tfcoach" tests="5" failures="4">
+  <testsuite name="a.tf" tests="3" failures="3">
+    <testcase name="core.naming_convention" classname="a.tf">
+      <failure message="Naming Convention (1 issue)" type="HIGH"><![CDATA[a.tf:10:2: m3
+Read more: https://marcel2603.github.io/tfcoach/rules/core/naming_convention]]></failure>
+    </testcase>
+    <testcase name="core.file_naming" classname="a.tf">
+      <failure message="File Naming (1 issue)" type="LOW"><![CDATA[a.tf:2:1: m4
+Read more: https://marcel2603.github.io/tfcoach/rules/core/file_naming]]></failure>
+    </testcase>
+    <testcase name="core.something_something" classname="a.tf">
+      <failure message="Unknown (1 issue)" type="UNKNOWN"><![CDATA[a.tf:4:7: m1
+Read more: about:blank]]></failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="b.tf" tests="1" failures="1">
+    <testcase name="core.naming_convention" classname="b.tf">
+      <failure message="Naming Convention (1 issue)" type="HIGH"><![CDATA[b.tf:9:2: m2
+Read more: https://marcel2603.github.io/tfcoach/rules/core/naming_convention]]></failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="c.tf" tests="1" failures="0">
+    <testcase name="no issues found" classname="c.tf"></testcase>
+  </testsuite>
+</testsuites>
+`
+
+	if got := buf.String(); got != want {
+		t.Fatalf("mismatch:\n got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteResults_JUnitWithoutIssues(t *testing.T) {
+	var buf bytes.Buffer
+	err := formatter.WriteResults(nil, nil, &buf, "junit", formatter.Options{AllowEmojis: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="tfcoach" tests="0" failures="0"></testsuites>
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("unexpected output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestReformatResults_JUnit(t *testing.T) {
+	var buf bytes.Buffer
+	err := formatter.ReformatResults([]byte(issues3Json), &buf, "junit", formatter.Options{AllowEmojis: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+
+	got := buf.String()
+	if !strings.Contains(got, `<testsuites name="tfcoach" tests="4" failures="4">`) {
+		t.Fatalf("unexpected testsuites header:\n%s", got)
+	}
+	if strings.Contains(got, "no issues found") {
+		t.Fatalf("report without linted files should not contain passing testcases:\n%s", got)
+	}
+}
+
+func TestWriteResults_GitLab(t *testing.T) {
+	var buf bytes.Buffer
+	err := formatter.WriteResults(issues2, nil, &buf, "gitlab", formatter.Options{AllowEmojis: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+
+	var got []map[string]interface{}
+	if err = json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Unexpected unmarshalling error: %v, want none", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("wanted 2 issues, got %d", len(got))
+	}
+
+	for _, issue := range got {
+		if fingerprint, ok := issue["fingerprint"].(string); !ok || fingerprint == "" {
+			t.Fatalf("missing fingerprint in %v", issue)
+		}
+		delete(issue, "fingerprint")
+	}
+	want := `[
+  {"description": "m1", "check_name": "core.something_something", "severity": "major", "location": {"path": "a.tf", "lines": {"begin": 4}}},
+  {"description": "m2", "check_name": "core.naming_convention", "severity": "critical", "location": {"path": "b.tf", "lines": {"begin": 9}}}
+]`
+	var wantJ []map[string]interface{}
+	if err = json.Unmarshal([]byte(want), &wantJ); err != nil {
+		t.Fatalf("Unexpected unmarshalling error in test setup: %v, want none", err)
+	}
+	if !reflect.DeepEqual(got, wantJ) {
+		t.Fatalf("JSON DeepEqual mismatch:\n got:\n%v\nwant:\n%v", got, wantJ)
+	}
+}
+
+func TestWriteResults_GitLabWithoutIssues(t *testing.T) {
+	var buf bytes.Buffer
+	err := formatter.WriteResults(nil, nil, &buf, "gitlab", formatter.Options{AllowEmojis: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+
+	if got := strings.TrimSpace(buf.String()); got != "[]" {
+		t.Fatalf("got %q, want an empty array", got)
+	}
+}
+
+func TestWriteResults_GitLabFingerprintStableAcrossLineShifts(t *testing.T) {
+	fingerprintsOf := func(issues []types.Issue) []string {
+		var buf bytes.Buffer
+		if err := formatter.WriteResults(issues, nil, &buf, "gitlab", formatter.Options{AllowEmojis: true}); err != nil {
+			t.Fatalf("Unexpected error: %v, want none", err)
+		}
+		var got []struct {
+			Fingerprint string `json:"fingerprint"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("Unexpected unmarshalling error: %v, want none", err)
+		}
+		var fingerprints []string
+		for _, issue := range got {
+			fingerprints = append(fingerprints, issue.Fingerprint)
+		}
+		return fingerprints
+	}
+
+	before := fingerprintsOf([]types.Issue{
+		{File: "a.tf", Range: rng("a.tf", 4, 1), Message: "m", RuleID: "core.naming_convention"},
+		{File: "a.tf", Range: rng("a.tf", 8, 1), Message: "m", RuleID: "core.naming_convention"},
+	})
+	after := fingerprintsOf([]types.Issue{
+		{File: "a.tf", Range: rng("a.tf", 14, 1), Message: "m", RuleID: "core.naming_convention"},
+		{File: "a.tf", Range: rng("a.tf", 18, 1), Message: "m", RuleID: "core.naming_convention"},
+	})
+
+	if !reflect.DeepEqual(before, after) {
+		t.Fatalf("fingerprints changed after line shift:\nbefore: %v\n after: %v", before, after)
+	}
+	if before[0] == before[1] {
+		t.Fatalf("identical issues should get distinct fingerprints, got %v", before)
+	}
+}
+
+func TestWriteResults_GitHub(t *testing.T) {
+	issues := []types.Issue{
+		{
+			File: "a.tf",
+			Range: hcl.Range{
+				Filename: "a.tf",
+				Start:    hcl.Pos{Line: 4, Column: 1},
+				End:      hcl.Pos{Line: 6, Column: 2},
+			},
+			Message: "multi\nline, 100%",
+			RuleID:  "core.naming_convention",
+		},
+		{File: "b,c.tf", Range: rng("b,c.tf", 2, 1), Message: "m2", RuleID: "core.file_naming"},
+		{File: "c.tf", Range: rng("c.tf", 3, 5), Message: "m3", RuleID: "core.enforce_parameter_order"},
+		{Message: "m4", RuleID: "core.something_something"},
+	}
+
+	var buf bytes.Buffer
+	err := formatter.WriteResults(issues, nil, &buf, "github", formatter.Options{AllowEmojis: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+
+	want := `::error file=a.tf,line=4,col=1,endLine=6,endColumn=2,title=Naming Convention::multi%0Aline, 100%25
+::notice file=b%2Cc.tf,line=2,col=1,endLine=2,endColumn=1,title=File Naming::m2
+::warning file=c.tf,line=3,col=5,endLine=3,endColumn=5,title=Enforce Parameter Order::m3
+::error title=Unknown::m4
+`
+
+	if got := buf.String(); got != want {
+		t.Fatalf("mismatch:\n got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteResults_Checkstyle(t *testing.T) {
+	var buf bytes.Buffer
+	err := formatter.WriteResults(issues3, nil, &buf, "checkstyle", formatter.Options{AllowEmojis: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<checkstyle version="4.3">
+  <file name="a.tf">
+    <error line="2" column="1" severity="info" message="m4" source="core.file_naming"></error>
+    <error line="4" column="7" severity="warning" message="m1" source="core.something_something"></error>
+    <error line="10" column="2" severity="error" message="m3" source="core.naming_convention"></error>
+  </file>
+  <file name="b.tf">
+    <error line="9" column="2" severity="error" message="m2" source="core.naming_convention"></error>
+  </file>
+</checkstyle>
+`
+
+	if got := buf.String(); got != want {
+		t.Fatalf("mismatch:\n got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestReformatResults_Checkstyle(t *testing.T) {
+	var want bytes.Buffer
+	err := formatter.WriteResults(issues3, nil, &want, "checkstyle", formatter.Options{AllowEmojis: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+
+	var buf bytes.Buffer
+	err = formatter.ReformatResults([]byte(issues3Json), &buf, "checkstyle", formatter.Options{AllowEmojis: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+
+	if got := buf.String(); got != want.String() {
+		t.Fatalf("mismatch:\n got:\n%s\nwant:\n%s", got, want.String())
+	}
+}
+
+func TestWriteResults_HTML(t *testing.T) {
+	sourceFile := filepath.Join("modules", "main.tf")
+	sources := map[string]string{sourceFile: "terraform {}\n\nresource \"a\" \"B\" {\n  x = 1\n}\n\nlocals {}\n"}
+	issues := []types.Issue{
+		{
+			File: sourceFile,
+			Range: hcl.Range{
+				Filename: sourceFile,
+				Start:    hcl.Pos{Line: 3, Column: 1},
+				End:      hcl.Pos{Line: 5, Column: 2},
+			},
+			Message: `Block "B" <violates> naming convention`,
+			RuleID:  "core.naming_convention",
+		},
+		{Message: "No backend configured", RuleID: "core.use_cloud_backend"},
+	}
+
+	var buf bytes.Buffer
+	err := formatter.WriteResults(issues, nil, &buf, "html", formatter.Options{AllowEmojis: true, ReadSource: readSourceFrom(sources)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+	got := buf.String()
+
+	wantContains := []string{
+		"<p>2 issues found in 2 files</p>",
+		`<tr><td><span class="severity high">HIGH</span></td><td>2</td></tr>`,
+		`<a href="#rule-core.naming_convention">Naming Convention</a>`,
+		"Terraform names should only contain lowercase alphanumeric characters and underscores.",
+		`<a href="https://marcel2603.github.io/tfcoach/rules/core/naming_convention">Read more</a>`,
+		"Block &#34;B&#34; &lt;violates&gt; naming convention",
+		`<span><span class="line-number">1</span>terraform {}</span>`,
+		`<span class="highlighted"><span class="line-number">3</span>resource &#34;a&#34; &#34;B&#34; {</span>`,
+		`<span class="highlighted"><span class="line-number">5</span>}</span>`,
+		`<span><span class="line-number">7</span>locals {}</span>`,
+		"(no file) (1 issue)",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(got, want) {
+			t.Errorf("HTML report does not contain %q", want)
+		}
+	}
+	if strings.Contains(got, `<span class="line-number">8</span>`) {
+		t.Errorf("HTML report contains lines outside of the snippet")
+	}
+}
+
+func TestReformatResults_HTMLWithoutSources(t *testing.T) {
+	var buf bytes.Buffer
+	err := formatter.ReformatResults([]byte(issues3Json), &buf, "html", formatter.Options{AllowEmojis: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+
+	got := buf.String()
+	if !strings.Contains(got, "<p>4 issues found in 2 files</p>") {
+		t.Fatalf("unexpected HTML summary:\n%s", got)
+	}
+	if strings.Contains(got, "<pre>") {
+		t.Fatalf("HTML report should not contain snippets for missing source files")
+	}
+}
+
+func TestReformatResults_HTMLWithCapturedSnippets(t *testing.T) {
+	issues := []types.Issue{
+		{
+			File:    "main.tf",
+			Range:   hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 3, Column: 1}, End: hcl.Pos{Line: 3, Column: 18}},
+			Message: "m1",
+			RuleID:  "core.naming_convention",
+		},
+	}
+	sources := map[string]string{"main.tf": "terraform {}\n\nresource \"a\" \"B\" {}\n"}
+	var report bytes.Buffer
+	err := formatter.WriteResults(issues, nil, &report, "json", formatter.Options{ReadSource: readSourceFrom(sources)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+
+	var buf bytes.Buffer
+	if err = formatter.ReformatResults(report.Bytes(), &buf, "html", formatter.Options{}); err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+	got := buf.String()
+	if !strings.Contains(got, `<span class="highlighted"><span class="line-number">3</span>resource &#34;a&#34; &#34;B&#34; {}</span>`) {
+		t.Errorf("HTML report does not contain the captured snippet:\n%s", got)
+	}
+	if strings.Contains(got, "terraform {}") {
+		t.Errorf("HTML report contains source lines that were not captured")
+	}
+}
+
+func TestWriteResults_Markdown(t *testing.T) {
+	var buf bytes.Buffer
+	err := formatter.WriteResults(issues3, nil, &buf, "markdown", formatter.Options{AllowEmojis: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+
+	want := `## tfcoach
+
+**3** rules broken (**4** issues total)
+
+| Rule | Severity | Issues |
+|------|----------|--------|
+| [Naming Convention](https://marcel2603.github.io/tfcoach/rules/core/naming_convention) | 🔴 **HIGH** | 2 |
+| [File Naming](https://marcel2603.github.io/tfcoach/rules/core/file_naming) | 🔵 **LOW** | 1 |
+| Unknown | ⚪ **UNKNOWN** | 1 |
+
+<details>
+<summary><b>Naming Convention</b> <code>core.naming_convention</code> (2 issues)</summary>
+
+Terraform names should only contain lowercase alphanumeric characters and underscores.
+
+- [a.tf:10:2](a.tf#L10) m3
+- [b.tf:9:2](b.tf#L9) m2
+
+</details>
+
+<details>
+<summary><b>File Naming</b> <code>core.file_naming</code> (1 issue)</summary>
+
+File naming should follow a strict convention.
+
+- [a.tf:2:1](a.tf#L2) m4
+
+</details>
+
+<details>
+<summary><b>Unknown</b> <code>core.something_something</code> (1 issue)</summary>
+
+Unknown rule
+
+- [a.tf:4:7](a.tf#L4) m1
+
+</details>
+`
+
+	if got := buf.String(); got != want {
+		t.Fatalf("mismatch:\n got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteResults_MarkdownTruncated(t *testing.T) {
+	var issues []types.Issue
+	for i := range 2000 {
+		issues = append(issues, types.Issue{
+			File:    "main.tf",
+			Range:   rng("main.tf", i+1, 1),
+			Message: strings.Repeat("x", 100),
+			RuleID:  "core.naming_convention",
+		})
+	}
+	issues = append(issues, types.Issue{File: "main.tf", Range: rng("main.tf", 1, 1), Message: "m", RuleID: "core.file_naming"})
+
+	var buf bytes.Buffer
+	err := formatter.WriteResults(issues, nil, &buf, "markdown", formatter.Options{AllowEmojis: false})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+	got := buf.String()
+
+	if len(got) > 60000 {
+		t.Fatalf("report is %d bytes long, want at most 60000", len(got))
+	}
+	listed := strings.Count(got, "\n- ")
+	omitted := len(issues) - listed
+	wantNote := fmt.Sprintf("_%d more issues omitted to keep this report short, see the full report for details._", omitted)
+	if !strings.Contains(got, wantNote) {
+		t.Fatalf("report does not contain %q", wantNote)
+	}
+	if strings.Count(got, "<details>") != strings.Count(got, "</details>") {
+		t.Fatalf("unbalanced <details> tags in truncated report")
+	}
+	if !strings.Contains(got, "| [File Naming](https://marcel2603.github.io/tfcoach/rules/core/file_naming) | **LOW** | 1 |") {
+		t.Fatalf("summary table should list all broken rules")
+	}
+}
+
+func TestWriteResults_MarkdownTruncatedAtSectionBoundary(t *testing.T) {
+	// the first issue fills the report up to the limit, byte by byte, so that the section of the second rule fits
+	// completely, partially or not at all
+	for messageLength := 58800; messageLength <= 59800; messageLength++ {
+		issues := []types.Issue{
+			{File: "main.tf", Range: rng("main.tf", 1, 1), Message: strings.Repeat("x", messageLength), RuleID: "core.naming_convention"},
+			{File: "main.tf", Range: rng("main.tf", 2, 1), Message: "m", RuleID: "core.file_naming"},
+		}
+		var buf bytes.Buffer
+		err := formatter.WriteResults(issues, nil, &buf, "markdown", formatter.Options{AllowEmojis: false})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v, want none", err)
+		}
+		got := buf.String()
+
+		if len(got) > 60000 {
+			t.Fatalf("message length %d: report is %d bytes long, want at most 60000", messageLength, len(got))
+		}
+		for _, section := range strings.Split(got, "<details>")[1:] {
+			if !strings.Contains(section[:strings.Index(section, "</details>")], "\n- ") {
+				t.Fatalf("message length %d: report contains a section without issues:\n%s", messageLength, section)
+			}
+		}
+		omitted := len(issues) - strings.Count(got, "\n- ")
+		wantNote := fmt.Sprintf("_%d more issue", omitted)
+		if omitted > 0 && !strings.Contains(got, wantNote) {
+			t.Fatalf("message length %d: report does not contain %q", messageLength, wantNote)
+		}
+	}
+}
+
+func TestWriteResults_Template(t *testing.T) {
+	templatePath := filepath.Join(t.TempDir(), "report.tmpl")
+	tmpl := `{{ .IssueCount }} issue{{ plural .IssueCount }}
+{{ range $file, $issues := groupByFile .Issues -}}
+{{ $file }}:
+{{ range $issues }}  {{ .Line }}:{{ .Column }} {{ upper .Severity.Str }} {{ (rule .RuleID).Title }}: {{ .Message }}
+{{ end -}}
+{{ end -}}
+{{ range $ruleID, $issues := groupByRule .Issues }}{{ $ruleID }}={{ len $issues }} {{ end }}
+`
+	if err := os.WriteFile(templatePath, []byte(tmpl), 0o644); err != nil {
+		t.Fatalf("Setup error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	err := formatter.WriteResults(issues3, nil, &buf, "template", formatter.Options{TemplatePath: templatePath})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+
+	want := `4 issues
+a.tf:
+  4:7 UNKNOWN Unknown: m1
+  10:2 HIGH Naming Convention: m3
+  2:1 LOW File Naming: m4
+b.tf:
+  9:2 HIGH Naming Convention: m2
+core.file_naming=1 core.naming_convention=2 core.something_something=1 
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("mismatch:\n got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteResults_TemplateErrors(t *testing.T) {
+	invalidTemplatePath := filepath.Join(t.TempDir(), "invalid.tmpl")
+	if err := os.WriteFile(invalidTemplatePath, []byte("{{ .Issues "), 0o644); err != nil {
+		t.Fatalf("Setup error: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		templatePath string
+	}{
+		{name: "no template", templatePath: ""},
+		{name: "missing template", templatePath: filepath.Join(t.TempDir(), "missing.tmpl")},
+		{name: "invalid template", templatePath: invalidTemplatePath},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := formatter.WriteResults(issues1, nil, &buf, "template", formatter.Options{TemplatePath: tt.templatePath})
+			if err == nil {
+				t.Fatalf("Expected error, got none")
+			}
+		})
+	}
+}
+
+func readSourceFrom(files map[string]string) func(path string) ([]byte, error) {
+	return func(path string) ([]byte, error) {
+		content, ok := files[path]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return []byte(content), nil
+	}
+}
+
+func TestWriteResults_JsonSnippets(t *testing.T) {
+	sources := map[string]string{
+		"main.tf": "locals {\n  a = 1\n  b = 2\n  c = 3\n  d = 4\n  e = 5\n}\n",
+	}
+	issues := []types.Issue{
+		{
+			File:    "main.tf",
+			Range:   hcl.Range{Start: hcl.Pos{Line: 2, Column: 3}, End: hcl.Pos{Line: 2, Column: 8}},
+			Message: "single line",
+			RuleID:  "core.naming_convention",
+		},
+		{
+			File:    "main.tf",
+			Range:   hcl.Range{Start: hcl.Pos{Line: 1, Column: 1}, End: hcl.Pos{Line: 7, Column: 2}},
+			Message: "many lines",
+			RuleID:  "core.naming_convention",
+		},
+		{File: "missing.tf", Range: rng("missing.tf", 1, 1), Message: "no source", RuleID: "core.naming_convention"},
+		{File: "main.tf", Range: rng("main.tf", 42, 1), Message: "out of range", RuleID: "core.naming_convention"},
+	}
+
+	var buf bytes.Buffer
+	err := formatter.WriteResults(issues, nil, &buf, "json", formatter.Options{ReadSource: readSourceFrom(sources)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+
+	type line struct {
+		Number int    `json:"number"`
+		Text   string `json:"text"`
+	}
+	var report struct {
+		Issues []struct {
+			Snippet *struct {
+				Lines []line `json:"lines"`
+			} `json:"snippet"`
+		} `json:"issues"`
+	}
+	if err = json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	wantLines := [][]line{
+		{{Number: 2, Text: "  a = 1"}},
+		{{Number: 1, Text: "locals {"}, {Number: 2, Text: "  a = 1"}, {Number: 6, Text: "  e = 5"}, {Number: 7, Text: "}"}},
+		nil,
+		nil,
+	}
+	for i, want := range wantLines {
+		snippet := report.Issues[i].Snippet
+		if want == nil {
+			if snippet != nil {
+				t.Errorf("issue %d: got snippet %v, want none", i, snippet)
+			}
+			continue
+		}
+		if snippet == nil || !reflect.DeepEqual(snippet.Lines, want) {
+			t.Errorf("issue %d: got snippet %v, want lines %v", i, snippet, want)
+		}
+	}
+}
+
+func TestWriteResults_PrettySnippet(t *testing.T) {
+	sources := map[string]string{
+		"main.tf": "resource \"null_resource\" \"tEst\" {}\n\nresource \"x\" \"y\" {\n\tname = \"a\"\n}\n",
+	}
+	issues := []types.Issue{
+		{
+			File:    "main.tf",
+			Range:   hcl.Range{Start: hcl.Pos{Line: 1, Column: 26}, End: hcl.Pos{Line: 1, Column: 32}},
+			Message: "m1",
+			RuleID:  "core.naming_convention",
+		},
+		{
+			File:    "main.tf",
+			Range:   hcl.Range{Start: hcl.Pos{Line: 4, Column: 2}, End: hcl.Pos{Line: 5, Column: 2}},
+			Message: "m2",
+			RuleID:  "core.naming_convention",
+		},
+	}
+
+	var buf bytes.Buffer
+	err := formatter.WriteResults(issues, nil, &buf, "pretty", formatter.Options{ReadSource: readSourceFrom(sources)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+
+	want := `Summary: 2 issues found in 1 file
+
+─── main.tf ────────────
+
+  1:26	[core.naming_convention]	HIGH
+	m1
+	Docs: https://marcel2603.github.io/tfcoach/rules/core/naming_convention
+
+	1 | resource "null_resource" "tEst" {}
+	  |                          ^^^^^^
+
+  4:2	[core.naming_convention]	HIGH
+	m2
+	Docs: https://marcel2603.github.io/tfcoach/rules/core/naming_convention
+
+	4 | 	name = "a"
+	  | 	^^^^^^^^^^
+	5 | }
+	  | ^
+
+`
+
+	if got := buf.String(); got != want {
+		t.Fatalf("mismatch:\n got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestReformatResults_EducationalSnippet(t *testing.T) {
+	report := `{
+  "issue_count": 1,
+  "issues": [
+    {
+      "file": "gone.tf",
+      "line": 3,
+      "column": 5,
+      "end_line": 3,
+      "end_column": 5,
+      "message": "m1",
+      "rule_id": "core.naming_convention",
+      "severity": {"str": "HIGH", "priority": 1},
+      "category": "",
+      "docs_url": "https://marcel2603.github.io/tfcoach/rules/core/naming_convention",
+      "snippet": {"lines": [{"number": 3, "text": "  a = 1"}]}
+    }
+  ]
+}`
+
+	var buf bytes.Buffer
+	err := formatter.ReformatResults([]byte(report), &buf, "educational", formatter.Options{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+
+	want := "- gone.tf:3:5 ─ m1\n    3 |   a = 1\n      |     ^\n"
+	if got := buf.String(); !strings.Contains(got, want) {
+		t.Fatalf("missing snippet:\n got: %q\nwant to contain: %q", got, want)
+	}
+}
+
+func TestWriteResults_JsonDetails(t *testing.T) {
+	issues := []types.Issue{
+		{
+			File:    "main.tf",
+			Range:   rng("main.tf", 3, 3),
+			Message: "`count` must come before `ami` in resource block \"web\"",
+			RuleID:  "core.enforce_parameter_order",
+			Details: map[string]any{"parameter": "count", "expected_order": []string{"count", "ami"}},
+		},
+		{File: "main.tf", Range: rng("main.tf", 5, 1), Message: "no details", RuleID: "core.naming_convention"},
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.WriteResults(issues, nil, &buf, "json", formatter.Options{}); err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+	if !strings.Contains(buf.String(), `"details": {
+        "expected_order": [
+          "count",
+          "ami"
+        ],
+        "parameter": "count"
+      }`) {
+		t.Errorf("Expected details in the first issue, got %s", buf.String())
+	}
+	if strings.Count(buf.String(), `"details"`) != 1 {
+		t.Errorf("Expected no details for issues without them, got %s", buf.String())
+	}
+
+	// details survive the conversion of reports
+	var converted bytes.Buffer
+	if err := formatter.ReformatResults(buf.Bytes(), &converted, "json", formatter.Options{}); err != nil {
+		t.Fatalf("Unexpected error: %v, want none", err)
+	}
+	if converted.String() != buf.String() {
+		t.Errorf("Conversion changed the report:\n%s\nwant\n%s", converted.String(), buf.String())
+	}
+}
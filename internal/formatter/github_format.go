@@ -0,0 +1,73 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Marcel2603/tfcoach/internal/constants"
+	"github.com/Marcel2603/tfcoach/internal/types"
+	"github.com/Marcel2603/tfcoach/rules/core"
+)
+
+var (
+	githubDataEscaper = strings.NewReplacer(
+		"%", "%25",
+		"\r", "%0D",
+		"\n", "%0A",
+	)
+	githubPropertyEscaper = strings.NewReplacer(
+		"%", "%25",
+		"\r", "%0D",
+		"\n", "%0A",
+		":", "%3A",
+		",", "%2C",
+	)
+)
+
+// writeGitHub prints GitHub Actions workflow commands, which get rendered as inline annotations on pull requests.
+// See https://docs.github.com/en/actions/reference/workflows-and-actions/workflow-commands
+func writeGitHub(issues []issueOutput, w io.Writer) error {
+	for _, issue := range issues {
+		rule, err := core.FindByID(issue.RuleID)
+		if err != nil {
+			rule = &core.UnknownRule{PseudoID: issue.RuleID}
+		}
+
+		var properties []string
+		if issue.File != "" {
+			properties = append(properties, "file="+githubPropertyEscaper.Replace(issue.File))
+			if issue.Line > 0 {
+				properties = append(properties, fmt.Sprintf("line=%d", issue.Line), fmt.Sprintf("col=%d", issue.Column))
+			}
+			if issue.EndLine > 0 {
+				properties = append(properties, fmt.Sprintf("endLine=%d", issue.EndLine), fmt.Sprintf("endColumn=%d", issue.EndColumn))
+			}
+		}
+		properties = append(properties, "title="+githubPropertyEscaper.Replace(rule.META().Title))
+
+		_, err = fmt.Fprintf(
+			w,
+			"::%s %s::%s\n",
+			githubCommand(issue.Severity),
+			strings.Join(properties, ","),
+			githubDataEscaper.Replace(issue.Message),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func githubCommand(severity types.Severity) string {
+	switch severity {
+	case constants.SeverityHigh, constants.SeverityUnknown:
+		// unknown severities come e.g. from files that could not be parsed
+		return "error"
+	case constants.SeverityLow, constants.SeverityInfo:
+		return "notice"
+	default:
+		return "warning"
+	}
+}
@@ -0,0 +1,95 @@
+package formatter
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Marcel2603/tfcoach/internal/constants"
+	"github.com/Marcel2603/tfcoach/internal/types"
+)
+
+type gitlabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitlabLocation `json:"location"`
+}
+
+type gitlabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitlabLines `json:"lines"`
+}
+
+type gitlabLines struct {
+	Begin int `json:"begin"`
+}
+
+func writeGitLab(issues []issueOutput, w io.Writer) error {
+	// fingerprints are assigned in order of appearance, so identical issues within one file stay distinguishable
+	sortedIssues := slices.Clone(issues)
+	slices.SortStableFunc(sortedIssues, func(a, b issueOutput) int {
+		if a.File != b.File {
+			return strings.Compare(a.File, b.File)
+		}
+		if a.Line != b.Line {
+			return cmp.Compare(a.Line, b.Line)
+		}
+		return cmp.Compare(a.Column, b.Column)
+	})
+
+	occurrences := make(map[string]int)
+	output := make([]gitlabIssue, 0, len(sortedIssues))
+	for _, issue := range sortedIssues {
+		path := filepath.ToSlash(issue.File)
+		key := strings.Join([]string{issue.RuleID, path, issue.Message}, "\x00")
+		output = append(output, gitlabIssue{
+			Description: issue.Message,
+			CheckName:   issue.RuleID,
+			Fingerprint: gitlabFingerprint(key, occurrences[key]),
+			Severity:    gitlabSeverity(issue.Severity),
+			Location: gitlabLocation{
+				Path:  path,
+				Lines: gitlabLines{Begin: max(issue.Line, 1)},
+			},
+		})
+		occurrences[key]++
+	}
+
+	outputAsStr, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(outputAsStr)
+	return err
+}
+
+// gitlabFingerprint deliberately ignores line numbers, so that a finding keeps its identity when unrelated lines
+// are added or removed above it.
+func gitlabFingerprint(key string, occurrence int) string {
+	sum := sha256.Sum256([]byte(key + "\x00" + strconv.Itoa(occurrence)))
+	return hex.EncodeToString(sum[:])
+}
+
+func gitlabSeverity(severity types.Severity) string {
+	switch severity {
+	case constants.SeverityHigh:
+		return "critical"
+	case constants.SeverityMedium:
+		return "major"
+	case constants.SeverityLow:
+		return "minor"
+	case constants.SeverityUnknown:
+		// e.g. files that could not be parsed
+		return "major"
+	default:
+		return "info"
+	}
+}
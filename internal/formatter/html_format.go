@@ -0,0 +1,163 @@
+package formatter
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/Marcel2603/tfcoach/internal/types"
+)
+
+const (
+	htmlSnippetContextLines = 2
+	htmlFilelessName        = "(no file)"
+)
+
+var (
+	//go:embed html_report.gohtml
+	htmlTemplateData string
+
+	htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+		"severityClass": func(severity types.Severity) string { return strings.ToLower(severity.String()) },
+		"plural":        CondPlural,
+	}).Parse(htmlTemplateData))
+)
+
+type htmlReport struct {
+	IssueCount int
+	BySeverity []htmlCount
+	ByRule     []htmlRule
+	Files      []htmlFile
+}
+
+type htmlCount struct {
+	Severity types.Severity
+	Count    int
+}
+
+type htmlRule struct {
+	ID          string
+	Title       string
+	Description string
+	Severity    types.Severity
+	DocsURL     string
+	Count       int
+}
+
+type htmlFile struct {
+	Name   string
+	Issues []htmlIssue
+}
+
+type htmlIssue struct {
+	issueOutput
+	Rule    htmlRule
+	Snippet []htmlSourceLine
+}
+
+type htmlSourceLine struct {
+	Number      int
+	Text        string
+	Highlighted bool
+}
+
+// writeHTML shows the source around every issue if readSource is set, and the snippets captured with the issues
+// otherwise, e.g. when a report is converted with "tfcoach print".
+func writeHTML(issues []issueOutput, readSource func(path string) ([]byte, error), w io.Writer) error {
+	var sources *sourceCache
+	if readSource != nil {
+		sources = newSourceCache(readSource)
+	}
+
+	issuesGroupedByRuleID := groupByRuleID(issues)
+	rulesByID := make(map[string]htmlRule, len(issuesGroupedByRuleID))
+	report := htmlReport{IssueCount: len(issues)}
+
+	for _, rule := range extractRulesSortedBySeverity(issuesGroupedByRuleID, nil) {
+		ruleMeta := rule.META()
+		issuesForRule := issuesGroupedByRuleID[rule.ID()]
+		r := htmlRule{
+			ID:          rule.ID(),
+			Title:       ruleMeta.Title,
+			Description: ruleMeta.Description,
+			Severity:    issuesForRule[0].Severity,
+			DocsURL:     issuesForRule[0].DocsURL,
+			Count:       len(issuesForRule),
+		}
+		rulesByID[r.ID] = r
+		report.ByRule = append(report.ByRule, r)
+	}
+
+	severityCounts := make(map[types.Severity]int)
+	issuesGroupedByFile := make(map[string][]issueOutput)
+	for _, issue := range issues {
+		severityCounts[issue.Severity]++
+		issuesGroupedByFile[issue.File] = append(issuesGroupedByFile[issue.File], issue)
+	}
+	for _, severity := range slices.SortedFunc(maps.Keys(severityCounts), types.Severity.Cmp) {
+		report.BySeverity = append(report.BySeverity, htmlCount{Severity: severity, Count: severityCounts[severity]})
+	}
+
+	for _, fileName := range slices.Sorted(maps.Keys(issuesGroupedByFile)) {
+		issuesInFile := issuesGroupedByFile[fileName]
+		slices.SortStableFunc(issuesInFile, func(a, b issueOutput) int {
+			return a.Severity.Cmp(b.Severity)
+		})
+
+		var sourceLines []string
+		if sources != nil && fileName != "" {
+			sourceLines = sources.lines(fileName)
+		}
+		file := htmlFile{Name: fileName}
+		if fileName == "" {
+			file.Name = htmlFilelessName
+		}
+		for _, issue := range issuesInFile {
+			file.Issues = append(file.Issues, htmlIssue{
+				issueOutput: issue,
+				Rule:        rulesByID[issue.RuleID],
+				Snippet:     snippetAround(sourceLines, issue),
+			})
+		}
+		report.Files = append(report.Files, file)
+	}
+
+	return htmlTemplate.Execute(w, report)
+}
+
+func snippetAround(sourceLines []string, issue issueOutput) []htmlSourceLine {
+	if issue.Line < 1 {
+		return nil
+	}
+	if issue.Line > len(sourceLines) {
+		return capturedSnippet(issue)
+	}
+	lastHighlighted := max(issue.Line, min(issue.EndLine, len(sourceLines)))
+	first := max(1, issue.Line-htmlSnippetContextLines)
+	last := min(len(sourceLines), lastHighlighted+htmlSnippetContextLines)
+
+	var snippet []htmlSourceLine
+	for number := first; number <= last; number++ {
+		snippet = append(snippet, htmlSourceLine{
+			Number:      number,
+			Text:        sourceLines[number-1],
+			Highlighted: number >= issue.Line && number <= lastHighlighted,
+		})
+	}
+	return snippet
+}
+
+// capturedSnippet highlights the lines captured with the issue, without the surrounding source.
+func capturedSnippet(issue issueOutput) []htmlSourceLine {
+	if issue.Snippet == nil {
+		return nil
+	}
+	var snippet []htmlSourceLine
+	for _, line := range issue.Snippet.Lines {
+		snippet = append(snippet, htmlSourceLine{Number: line.Number, Text: line.Text, Highlighted: true})
+	}
+	return snippet
+}
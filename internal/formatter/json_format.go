@@ -3,14 +3,103 @@ package formatter
 import (
 	"encoding/json"
 	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Marcel2603/tfcoach/internal/types"
+	"github.com/Marcel2603/tfcoach/rules/core"
 )
 
-func writeJSON(issues []issueOutput, w io.Writer) error {
+type jsonOutput struct {
+	SchemaVersion int           `json:"schema_version"`
+	Tfcoach       *buildOutput  `json:"tfcoach,omitempty"`
+	Run           *runOutput    `json:"run,omitempty"`
+	IssueCount    int           `json:"issue_count"`
+	Issues        []issueOutput `json:"issues"`
+}
+
+type buildOutput struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+}
+
+type runOutput struct {
+	Root      string       `json:"root"`
+	FileCount int          `json:"file_count"`
+	Timing    timingOutput `json:"timing"`
+	Rules     []ruleOutput `json:"rules"`
+	Config    any          `json:"config"`
+}
+
+type timingOutput struct {
+	StartedAt  time.Time `json:"started_at"`
+	DurationMs int64     `json:"duration_ms"`
+}
+
+type ruleOutput struct {
+	ID          string         `json:"id"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Severity    types.Severity `json:"severity"`
+	DocsURL     string         `json:"docs_url"`
+	Enabled     bool           `json:"enabled"`
+}
+
+func newJSONOutput(issues []issueOutput, run *RunInfo) jsonOutput {
 	output := jsonOutput{
-		IssueCount: len(issues),
-		Issues:     issues,
+		SchemaVersion: reportSchemaVersion,
+		IssueCount:    len(issues),
+		Issues:        issues,
+	}
+	if run == nil {
+		return output
 	}
-	outputAsStr, err := json.MarshalIndent(output, "", "  ")
+
+	output.Tfcoach = &buildOutput{Version: run.BuildVersion, Commit: run.BuildCommit}
+	output.Run = &runOutput{
+		Root:      run.Root,
+		FileCount: run.FileCount,
+		Timing: timingOutput{
+			StartedAt:  run.StartedAt,
+			DurationMs: run.Duration.Milliseconds(),
+		},
+		Rules:  rulesOfRun(run.Rules),
+		Config: run.Config,
+	}
+	return output
+}
+
+// rulesOfRun lists all known rules and marks the ones that did not run as disabled.
+func rulesOfRun(executedRules []types.Rule) []ruleOutput {
+	rulesByID := make(map[string]ruleOutput)
+	for _, rule := range core.All() {
+		rulesByID[rule.ID()] = newRuleOutput(rule, false)
+	}
+	for _, rule := range executedRules {
+		rulesByID[rule.ID()] = newRuleOutput(rule, true)
+	}
+
+	return slices.SortedFunc(maps.Values(rulesByID), func(a, b ruleOutput) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+}
+
+func newRuleOutput(rule types.Rule, enabled bool) ruleOutput {
+	ruleMeta := rule.META()
+	return ruleOutput{
+		ID:          rule.ID(),
+		Title:       ruleMeta.Title,
+		Description: ruleMeta.Description,
+		Severity:    ruleMeta.Severity,
+		DocsURL:     ruleDocsURL(ruleMeta),
+		Enabled:     enabled,
+	}
+}
+
+func writeJSON(report jsonOutput, w io.Writer) error {
+	outputAsStr, err := json.MarshalIndent(report, "", "  ")
 	if err != nil {
 		return err
 	}
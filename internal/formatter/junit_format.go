@@ -0,0 +1,108 @@
+package formatter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+const (
+	junitSuitesName      = "tfcoach"
+	junitFilelessSuite   = "tfcoach"
+	junitPassingTestCase = "no issues found"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",cdata"`
+}
+
+func writeJUnit(issues []issueOutput, lintedFiles []string, w io.Writer) error {
+	issuesGroupedByFile := make(map[string][]issueOutput)
+	for _, issue := range issues {
+		fileName := issue.File
+		if fileName == "" {
+			fileName = junitFilelessSuite
+		}
+		issuesGroupedByFile[fileName] = append(issuesGroupedByFile[fileName], issue)
+	}
+	for _, fileName := range lintedFiles {
+		if _, ok := issuesGroupedByFile[fileName]; !ok {
+			issuesGroupedByFile[fileName] = nil
+		}
+	}
+
+	output := junitTestSuites{Name: junitSuitesName}
+	for _, fileName := range slices.Sorted(maps.Keys(issuesGroupedByFile)) {
+		suite := junitSuiteForFile(fileName, issuesGroupedByFile[fileName])
+		output.Tests += suite.Tests
+		output.Failures += suite.Failures
+		output.Suites = append(output.Suites, suite)
+	}
+
+	outputAsStr, err := xml.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s%s\n", xml.Header, outputAsStr)
+	return err
+}
+
+func junitSuiteForFile(fileName string, issuesInFile []issueOutput) junitTestSuite {
+	suite := junitTestSuite{Name: fileName}
+	if len(issuesInFile) == 0 {
+		suite.Tests = 1
+		suite.TestCases = []junitTestCase{{Name: junitPassingTestCase, ClassName: fileName}}
+		return suite
+	}
+
+	issuesGroupedByRuleID := groupByRuleID(issuesInFile)
+	for _, rule := range extractRulesSortedBySeverity(issuesGroupedByRuleID, nil) {
+		ruleMeta := rule.META()
+		issuesForRule := issuesGroupedByRuleID[rule.ID()]
+
+		var text strings.Builder
+		for _, issue := range issuesForRule {
+			_, _ = fmt.Fprintf(&text, "%s:%d:%d: %s\n", issue.File, issue.Line, issue.Column, issue.Message)
+		}
+		_, _ = fmt.Fprintf(&text, "Read more: %s", issuesForRule[0].DocsURL)
+
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      rule.ID(),
+			ClassName: fileName,
+			Failure: &junitFailure{
+				Message: fmt.Sprintf("%s (%d issue%s)", ruleMeta.Title, len(issuesForRule), CondPlural(len(issuesForRule))),
+				Type:    issuesForRule[0].Severity.String(),
+				Text:    text.String(),
+			},
+		})
+	}
+	suite.Tests = len(suite.TestCases)
+	suite.Failures = len(suite.TestCases)
+	return suite
+}
@@ -0,0 +1,134 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/Marcel2603/tfcoach/internal/constants"
+	"github.com/Marcel2603/tfcoach/internal/types"
+)
+
+const (
+	// GitHub rejects comments longer than 65536 characters, leave some room for whatever wraps the report
+	markdownMaxLength = 60000
+	// space kept free for closing tags and the note about omitted issues
+	markdownReservedLength = 200
+)
+
+var markdownTableEscaper = strings.NewReplacer("|", `\|`, "\n", " ")
+
+func writeMarkdown(issues []issueOutput, allowEmojis bool, w io.Writer) error {
+	issuesGroupedByRuleID := groupByRuleID(issues)
+	brokenRules := extractRulesSortedBySeverity(issuesGroupedByRuleID, nil)
+
+	var out strings.Builder
+	_, _ = fmt.Fprintf(
+		&out,
+		"## tfcoach\n\n**%d** rule%s broken (**%d** issue%s total)\n\n| Rule | Severity | Issues |\n|------|----------|--------|\n",
+		len(brokenRules),
+		CondPlural(len(brokenRules)),
+		len(issues),
+		CondPlural(len(issues)),
+	)
+	for _, rule := range brokenRules {
+		ruleMeta := rule.META()
+		issuesForRule := issuesGroupedByRuleID[rule.ID()]
+		_, _ = fmt.Fprintf(
+			&out,
+			"| %s | %s | %d |\n",
+			markdownLink(markdownTableEscaper.Replace(ruleMeta.Title), issuesForRule[0].DocsURL),
+			markdownSeverityBadge(issuesForRule[0].Severity, allowEmojis),
+			len(issuesForRule),
+		)
+	}
+
+	omitted := 0
+	for _, rule := range brokenRules {
+		issuesForRule := issuesGroupedByRuleID[rule.ID()]
+		if omitted > 0 {
+			omitted += len(issuesForRule)
+			continue
+		}
+		slices.SortStableFunc(issuesForRule, func(a, b issueOutput) int {
+			return strings.Compare(a.File, b.File)
+		})
+
+		ruleMeta := rule.META()
+		sectionHeader := fmt.Sprintf(
+			"\n<details>\n<summary><b>%s</b> <code>%s</code> (%d issue%s)</summary>\n\n%s\n\n",
+			ruleMeta.Title,
+			rule.ID(),
+			len(issuesForRule),
+			CondPlural(len(issuesForRule)),
+			ruleMeta.Description,
+		)
+		// the header and the summary table written so far count towards the limit as well
+		sectionLength := out.Len() + len(sectionHeader)
+		var lines []string
+		for i, issue := range issuesForRule {
+			line := fmt.Sprintf("- %s %s\n", markdownIssueLocation(issue), issue.Message)
+			if sectionLength+len(line) > markdownMaxLength-markdownReservedLength {
+				omitted += len(issuesForRule) - i
+				break
+			}
+			lines = append(lines, line)
+			sectionLength += len(line)
+		}
+		if len(lines) == 0 {
+			continue
+		}
+
+		_, _ = out.WriteString(sectionHeader)
+		for _, line := range lines {
+			_, _ = out.WriteString(line)
+		}
+		_, _ = out.WriteString("\n</details>\n")
+	}
+
+	if omitted > 0 {
+		_, _ = fmt.Fprintf(
+			&out,
+			"\n_%d more issue%s omitted to keep this report short, see the full report for details._\n",
+			omitted,
+			CondPlural(omitted),
+		)
+	}
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+func markdownIssueLocation(issue issueOutput) string {
+	if issue.File == "" {
+		return "(no file)"
+	}
+	location := fmt.Sprintf("%s:%d:%d", issue.File, issue.Line, issue.Column)
+	return markdownLink(location, fmt.Sprintf("%s#L%d", issue.File, issue.Line))
+}
+
+func markdownLink(text string, url string) string {
+	if url == "" || url == "about:blank" {
+		return text
+	}
+	return fmt.Sprintf("[%s](%s)", text, url)
+}
+
+func markdownSeverityBadge(severity types.Severity, allowEmojis bool) string {
+	if !allowEmojis {
+		return fmt.Sprintf("**%s**", severity)
+	}
+	var badge string
+	switch severity {
+	case constants.SeverityHigh:
+		badge = "🔴"
+	case constants.SeverityMedium:
+		badge = "🟠"
+	case constants.SeverityLow:
+		badge = "🔵"
+	default:
+		badge = "⚪"
+	}
+	return fmt.Sprintf("%s **%s**", badge, severity)
+}
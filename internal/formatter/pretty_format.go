@@ -22,9 +22,9 @@ func writePretty(issues []issueOutput, allowEmojis bool, w io.Writer) error {
 		w,
 		"Summary: %s issue%s found in %s file%s\n\n",
 		boldFont.Sprint(len(issues)),
-		condPlural(len(issues)),
+		CondPlural(len(issues)),
 		boldFont.Sprint(len(issuesGroupedByFile)),
-		condPlural(len(issuesGroupedByFile)),
+		CondPlural(len(issuesGroupedByFile)),
 	)
 	if err != nil {
 		return err
@@ -46,7 +46,7 @@ func writePretty(issues []issueOutput, allowEmojis bool, w io.Writer) error {
 		for _, issue := range issuesInFile {
 			_, err = fmt.Fprintf(
 				w,
-				"  %d:%d\t%s\t%s\n\t%s%s\n\t%s%s\n\n",
+				"  %d:%d\t%s\t%s\n\t%s%s\n\t%s%s\n",
 				issue.Line,
 				issue.Column,
 				boldFont.Sprint("["+issue.RuleID+"]"),
@@ -59,6 +59,20 @@ func writePretty(issues []issueOutput, allowEmojis bool, w io.Writer) error {
 			if err != nil {
 				return err
 			}
+			if issue.Snippet != nil {
+				_, err = fmt.Fprintln(w)
+				if err != nil {
+					return err
+				}
+				err = writeSnippet(issue, "\t", w)
+				if err != nil {
+					return err
+				}
+			}
+			_, err = fmt.Fprintln(w)
+			if err != nil {
+				return err
+			}
 		}
 	}
 	return nil
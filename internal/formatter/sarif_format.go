@@ -0,0 +1,179 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/Marcel2603/tfcoach/internal/constants"
+	"github.com/Marcel2603/tfcoach/internal/types"
+	"github.com/Marcel2603/tfcoach/rules/core"
+)
+
+const (
+	sarifSchema         = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion        = "2.1.0"
+	sarifToolName       = "tfcoach"
+	sarifInformationURI = "https://marcel2603.github.io/tfcoach"
+)
+
+type sarifOutput struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string                 `json:"id"`
+	Name                 string                 `json:"name"`
+	ShortDescription     sarifMessage           `json:"shortDescription"`
+	FullDescription      sarifMessage           `json:"fullDescription"`
+	HelpURI              string                 `json:"helpUri,omitempty"`
+	DefaultConfiguration sarifRuleConfiguration `json:"defaultConfiguration"`
+	Properties           sarifRuleProperties    `json:"properties"`
+}
+
+type sarifRuleConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifRuleProperties struct {
+	Severity string `json:"severity"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex *int            `json:"ruleIndex,omitempty"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+func writeSARIF(issues []issueOutput, w io.Writer) error {
+	allRules := core.All()
+	sarifRules := make([]sarifRule, 0, len(allRules))
+	ruleIndices := make(map[string]int, len(allRules))
+	for i, rule := range allRules {
+		ruleMeta := rule.META()
+		ruleIndices[rule.ID()] = i
+		sarifRules = append(sarifRules, sarifRule{
+			ID:                   rule.ID(),
+			Name:                 ruleMeta.Title,
+			ShortDescription:     sarifMessage{Text: ruleMeta.Title},
+			FullDescription:      sarifMessage{Text: ruleMeta.Description},
+			HelpURI:              fmt.Sprintf(ruleDocsFormat, ruleMeta.DocsURI),
+			DefaultConfiguration: sarifRuleConfiguration{Level: sarifLevel(ruleMeta.Severity)},
+			Properties:           sarifRuleProperties{Severity: ruleMeta.Severity.String()},
+		})
+	}
+
+	results := make([]sarifResult, 0, len(issues))
+	for _, issue := range issues {
+		result := sarifResult{
+			RuleID:  issue.RuleID,
+			Level:   sarifLevel(issue.Severity),
+			Message: sarifMessage{Text: issue.Message},
+		}
+		if index, ok := ruleIndices[issue.RuleID]; ok {
+			result.RuleIndex = &index
+		}
+		if issue.File != "" {
+			result.Locations = []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(issue.File)},
+					Region:           sarifRegionOf(issue),
+				},
+			}}
+		}
+		results = append(results, result)
+	}
+
+	output := sarifOutput{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           sarifToolName,
+				InformationURI: sarifInformationURI,
+				Rules:          sarifRules,
+			}},
+			Results: results,
+		}},
+	}
+	outputAsStr, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(outputAsStr)
+	return err
+}
+
+// sarifRegionOf returns nil for issues without a position (e.g. issues emitted on Finish), since SARIF requires
+// line numbers to start at 1.
+func sarifRegionOf(issue issueOutput) *sarifRegion {
+	if issue.Line < 1 {
+		return nil
+	}
+	region := &sarifRegion{
+		StartLine:   issue.Line,
+		StartColumn: issue.Column,
+	}
+	if issue.EndLine >= issue.Line {
+		region.EndLine = issue.EndLine
+		region.EndColumn = issue.EndColumn
+	}
+	return region
+}
+
+func sarifLevel(severity types.Severity) string {
+	switch severity {
+	case constants.SeverityHigh:
+		return "error"
+	case constants.SeverityMedium:
+		return "warning"
+	case constants.SeverityLow, constants.SeverityInfo:
+		return "note"
+	default:
+		return "none"
+	}
+}
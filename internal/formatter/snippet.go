@@ -0,0 +1,161 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+)
+
+const (
+	// ranges spanning more lines than this only keep their first and last lines
+	snippetMaxLines  = 5
+	snippetEdgeLines = 2
+)
+
+type snippetOutput struct {
+	Lines []snippetLine `json:"lines"`
+}
+
+type snippetLine struct {
+	Number int    `json:"number"`
+	Text   string `json:"text"`
+}
+
+// sourceCache reads every source file at most once while snippets are captured.
+type sourceCache struct {
+	readSource func(path string) ([]byte, error)
+	files      map[string][]string
+}
+
+func newSourceCache(readSource func(path string) ([]byte, error)) *sourceCache {
+	return &sourceCache{readSource: readSource, files: make(map[string][]string)}
+}
+
+func (c *sourceCache) lines(path string) []string {
+	if lines, ok := c.files[path]; ok {
+		return lines
+	}
+	var lines []string
+	if content, err := c.readSource(path); err == nil {
+		lines = strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+	}
+	c.files[path] = lines
+	return lines
+}
+
+// captureSnippet copies the source lines covered by an issue, so that reports can show them without access to the
+// linted files.
+func (c *sourceCache) captureSnippet(issue issueOutput) *snippetOutput {
+	if c == nil || issue.File == "" || issue.Line < 1 {
+		return nil
+	}
+	sourceLines := c.lines(issue.File)
+	if issue.Line > len(sourceLines) {
+		return nil
+	}
+
+	lastLine := min(max(issue.EndLine, issue.Line), len(sourceLines))
+	var numbers []int
+	if lastLine-issue.Line < snippetMaxLines {
+		for number := issue.Line; number <= lastLine; number++ {
+			numbers = append(numbers, number)
+		}
+	} else {
+		for number := issue.Line; number < issue.Line+snippetEdgeLines; number++ {
+			numbers = append(numbers, number)
+		}
+		for number := lastLine - snippetEdgeLines + 1; number <= lastLine; number++ {
+			numbers = append(numbers, number)
+		}
+	}
+
+	snippet := &snippetOutput{}
+	for _, number := range numbers {
+		snippet.Lines = append(snippet.Lines, snippetLine{Number: number, Text: sourceLines[number-1]})
+	}
+	return snippet
+}
+
+// writeSnippet prints the captured source lines with line numbers and marks the range of the issue with carets.
+func writeSnippet(issue issueOutput, indent string, w io.Writer) error {
+	if issue.Snippet == nil || len(issue.Snippet.Lines) == 0 {
+		return nil
+	}
+
+	lastNumber := issue.Snippet.Lines[len(issue.Snippet.Lines)-1].Number
+	gutterWidth := len(fmt.Sprint(lastNumber))
+	emptyGutter := greyColor.Sprint(strings.Repeat(" ", gutterWidth) + " | ")
+	caretColor := color.New(issue.Severity.Color(), color.Bold)
+
+	previousNumber := 0
+	for _, line := range issue.Snippet.Lines {
+		if previousNumber != 0 && line.Number > previousNumber+1 {
+			if _, err := fmt.Fprintf(w, "%s%s...\n", indent, emptyGutter); err != nil {
+				return err
+			}
+		}
+		previousNumber = line.Number
+
+		gutter := greyColor.Sprintf("%*d | ", gutterWidth, line.Number)
+		if _, err := fmt.Fprintf(w, "%s%s%s\n", indent, gutter, line.Text); err != nil {
+			return err
+		}
+
+		from, to, ok := caretColumns(issue, line)
+		if !ok {
+			continue
+		}
+		_, err := fmt.Fprintf(
+			w,
+			"%s%s%s%s\n",
+			indent,
+			emptyGutter,
+			whitespacePrefix(line.Text, from-1),
+			caretColor.Sprint(strings.Repeat("^", max(to-from, 1))),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// caretColumns returns the 1-based, end-exclusive columns to mark in the given line. Only the first and the last line
+// of a range get markers.
+func caretColumns(issue issueOutput, line snippetLine) (int, int, bool) {
+	lineEnd := utf8.RuneCountInString(line.Text) + 1
+	endLine := max(issue.EndLine, issue.Line)
+	switch {
+	case line.Number == issue.Line && line.Number == endLine:
+		return issue.Column, max(issue.EndColumn, issue.Column+1), true
+	case line.Number == issue.Line:
+		return issue.Column, lineEnd, true
+	case line.Number == endLine:
+		firstNonSpace := len([]rune(line.Text)) - len([]rune(strings.TrimLeft(line.Text, " \t"))) + 1
+		return firstNonSpace, issue.EndColumn, true
+	default:
+		return 0, 0, false
+	}
+}
+
+// whitespacePrefix returns n characters of padding, keeping tabs of text so that carets line up with the source.
+func whitespacePrefix(text string, n int) string {
+	var prefix strings.Builder
+	for i, r := range []rune(text) {
+		if i >= n {
+			break
+		}
+		if r == '\t' {
+			_, _ = prefix.WriteRune('\t')
+		} else {
+			_, _ = prefix.WriteRune(' ')
+		}
+	}
+	for i := utf8.RuneCountInString(text); i < n; i++ {
+		_, _ = prefix.WriteRune(' ')
+	}
+	return prefix.String()
+}
@@ -0,0 +1,85 @@
+package formatter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/Marcel2603/tfcoach/internal/types"
+	"github.com/Marcel2603/tfcoach/rules/core"
+	"github.com/fatih/color"
+)
+
+var templateFuncs = template.FuncMap{
+	"groupByFile": groupByFile,
+	"groupByRule": groupByRuleID,
+	"rule":        ruleMetaByID,
+	"severityColor": func(severity types.Severity, text string) string {
+		return color.New(severity.Color(), color.Bold).Sprint(text)
+	},
+	"bold":    func(text string) string { return boldFont.Sprint(text) },
+	"grey":    func(text string) string { return greyColor.Sprint(text) },
+	"relPath": relPath,
+	"plural":  CondPlural,
+	"join":    strings.Join,
+	"upper":   strings.ToUpper,
+	"lower":   strings.ToLower,
+	"repeat":  strings.Repeat,
+}
+
+// writeTemplate renders a user-defined text/template with the same data as the JSON report.
+func writeTemplate(report jsonOutput, templatePath string, w io.Writer) error {
+	if templatePath == "" {
+		return errors.New("the template format requires a template file")
+	}
+	templateData, err := os.ReadFile(templatePath)
+	if err != nil {
+		return fmt.Errorf("could not read template: %w", err)
+	}
+	tmpl, err := template.New(filepath.Base(templatePath)).Funcs(templateFuncs).Parse(string(templateData))
+	if err != nil {
+		return fmt.Errorf("could not parse template: %w", err)
+	}
+
+	return tmpl.Execute(w, report)
+}
+
+func groupByFile(issues []issueOutput) map[string][]issueOutput {
+	issuesGroupedByFile := make(map[string][]issueOutput)
+	for _, issue := range issues {
+		issuesGroupedByFile[issue.File] = append(issuesGroupedByFile[issue.File], issue)
+	}
+	return issuesGroupedByFile
+}
+
+func ruleMetaByID(ruleID string) types.RuleMeta {
+	rule, err := core.FindByID(ruleID)
+	if err != nil {
+		rule = &core.UnknownRule{PseudoID: ruleID}
+	}
+	return rule.META()
+}
+
+// relPath returns path relative to the working directory, or path itself if that is not possible.
+func relPath(path string) string {
+	if path == "" {
+		return path
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(wd, absPath)
+	if err != nil {
+		return path
+	}
+	return rel
+}
@@ -0,0 +1,39 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Marcel2603/tfcoach/internal/engine"
+	"github.com/Marcel2603/tfcoach/internal/types"
+)
+
+// CreateBaseline records all current issues in a new baseline at baselinePath, replacing an existing one.
+func CreateBaseline(
+	path string,
+	src engine.Source,
+	rules []types.Rule,
+	ruleResolver engine.RuleResolver,
+	w io.Writer,
+	baselinePath string,
+) int {
+	eng := engine.New(src)
+	eng.RegisterMany(rules)
+	if ruleResolver != nil {
+		eng.UseRuleResolver(ruleResolver)
+	}
+	_, err := eng.Run(path)
+	if err != nil {
+		_, _ = fmt.Fprintf(w, "error: %v\n", err)
+		return 2
+	}
+
+	baseline := eng.BaselineSnapshot()
+	if err = baseline.Save(baselinePath); err != nil {
+		_, _ = fmt.Fprintf(w, "error: failed to write baseline: %v\n", err)
+		return 2
+	}
+
+	_, _ = fmt.Fprintf(w, "Baseline with %d issues written to %s\n", baseline.IssueCount(), baselinePath)
+	return 0
+}
@@ -1,31 +1,174 @@
 package runner
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"os"
+	"time"
 
+	"github.com/Marcel2603/tfcoach/internal/constants"
 	"github.com/Marcel2603/tfcoach/internal/engine"
+	"github.com/Marcel2603/tfcoach/internal/engine/processor"
 	"github.com/Marcel2603/tfcoach/internal/formatter"
 	"github.com/Marcel2603/tfcoach/internal/types"
+	"github.com/fatih/color"
 )
 
-func Lint(path string, src engine.Source, rules []types.Rule, w io.Writer, outputFormat string, allowEmojis bool) int {
+// Output describes one report written by Lint. An empty Path writes to the writer passed to Lint.
+type Output struct {
+	Format   string
+	Path     string
+	Color    bool
+	Emojis   bool
+	Template string
+}
+
+// BaselineOptions controls the baseline used by Lint. An empty Path lints without baseline.
+type BaselineOptions struct {
+	Path string
+	// Prune rewrites the baseline without the issues that have been fixed.
+	Prune bool
+}
+
+// FailPolicy decides which issues fail a lint run. All issues are reported regardless.
+type FailPolicy struct {
+	// FailOn is the lowest severity that fails the run (high, medium, low or info), "never" lets every run pass.
+	// Issues of unknown severity, like parse errors, fail the run unless FailOn is "never".
+	FailOn string
+	// MaxIssues is the number of failing issues tolerated before the run fails.
+	MaxIssues int
+}
+
+// Lint runs the rules on path and writes all outputs. Files below configuration files get the rules of ruleResolver
+// instead, if set. runInfo only needs the details Lint cannot know itself (build and configuration), the rest is
+// filled in.
+func Lint(
+	path string,
+	src engine.Source,
+	rules []types.Rule,
+	ruleResolver engine.RuleResolver,
+	w io.Writer,
+	outputs []Output,
+	runInfo formatter.RunInfo,
+	baselineOptions BaselineOptions,
+	failPolicy FailPolicy,
+) int {
 	eng := engine.New(src)
 	eng.RegisterMany(rules)
+	if ruleResolver != nil {
+		eng.UseRuleResolver(ruleResolver)
+	}
+
+	var baseline *processor.Baseline
+	if baselineOptions.Path != "" {
+		var err error
+		baseline, err = processor.LoadBaseline(baselineOptions.Path)
+		if err != nil {
+			_, _ = fmt.Fprintf(w, "error: %v\n", err)
+			return 2
+		}
+		eng.UseBaseline(baseline)
+	}
+
+	startedAt := time.Now()
 	issues, err := eng.Run(path)
 	if err != nil {
 		_, _ = fmt.Fprintf(w, "error: %v\n", err)
 		return 2
 	}
 
-	if len(issues) > 0 {
-		writeErr := formatter.WriteResults(issues, w, outputFormat, allowEmojis)
+	if baseline != nil && baselineOptions.Prune {
+		pruned := baseline.Prune(eng.BaselineSnapshot(), path, eng.LintedFiles())
+		if err = pruned.Save(baselineOptions.Path); err != nil {
+			slog.Error("error pruning baseline", "path", baselineOptions.Path, "err", err)
+			return 2
+		}
+		slog.Info(
+			"pruned baseline",
+			"path", baselineOptions.Path,
+			"removed", baseline.IssueCount()-pruned.IssueCount(),
+			"remaining", pruned.IssueCount(),
+		)
+	}
+
+	runInfo.Root = path
+	runInfo.Rules = eng.Rules()
+	runInfo.FileCount = len(eng.LintedFiles())
+	runInfo.StartedAt = startedAt
+	runInfo.Duration = time.Since(startedAt)
+
+	defer func(noColor bool) { color.NoColor = noColor }(color.NoColor)
+	for _, output := range outputs {
+		// files and machine-readable formats are always written, so that CI artifacts never contain stale results of a
+		// previous run and tools always get a document to parse
+		if output.Path == "" && len(issues) == 0 && formatter.IsHumanReadable(output.Format) {
+			continue
+		}
+		color.NoColor = !output.Color
+		writeErr := writeOutput(issues, eng.LintedFiles(), src, w, output, &runInfo)
 		if writeErr != nil {
-			slog.Error("error writing results", "err", writeErr)
+			slog.Error("error writing results", "format", output.Format, "path", output.Path, "err", writeErr)
 			return 2
 		}
+	}
+
+	if failPolicy.fails(issues, eng.Rules()) {
 		return 1
 	}
 	return 0
 }
+
+func (p FailPolicy) fails(issues []types.Issue, rules []types.Rule) bool {
+	if p.FailOn == "never" {
+		return false
+	}
+	threshold, err := constants.ParseSeverity(p.FailOn)
+	if err != nil {
+		threshold = constants.SeverityLow
+	}
+
+	// a rule has the same severity in all rule sets, see core.EnabledRulesFor
+	severityByRuleID := make(map[string]types.Severity, len(rules))
+	for _, rule := range rules {
+		severityByRuleID[rule.ID()] = rule.META().Severity
+	}
+
+	failingIssues := 0
+	for _, issue := range issues {
+		severity, ok := severityByRuleID[issue.RuleID]
+		if !ok || severity.Cmp(threshold) <= 0 {
+			failingIssues++
+		}
+	}
+	return failingIssues > p.MaxIssues
+}
+
+func writeOutput(
+	issues []types.Issue,
+	lintedFiles []string,
+	src engine.Source,
+	w io.Writer,
+	output Output,
+	runInfo *formatter.RunInfo,
+) (err error) {
+	options := formatter.Options{
+		AllowEmojis:  output.Emojis,
+		TemplatePath: output.Template,
+		ReadSource:   src.ReadFile,
+		Run:          runInfo,
+	}
+	if output.Path == "" {
+		return formatter.WriteResults(issues, lintedFiles, w, output.Format, options)
+	}
+
+	f, err := os.Create(output.Path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+	return formatter.WriteResults(issues, lintedFiles, f, output.Format, options)
+}
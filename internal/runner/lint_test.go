@@ -2,9 +2,15 @@ package runner_test
 
 import (
 	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/Marcel2603/tfcoach/internal/constants"
+	"github.com/Marcel2603/tfcoach/internal/engine"
+	"github.com/Marcel2603/tfcoach/internal/formatter"
 	"github.com/Marcel2603/tfcoach/internal/runner"
 	"github.com/Marcel2603/tfcoach/internal/testutil"
 	"github.com/Marcel2603/tfcoach/internal/types"
@@ -14,7 +20,7 @@ func TestRunLint_NoIssues(t *testing.T) {
 	src := testutil.MemSource{Files: map[string]string{"ok.tf": `# nothing`}}
 	var rules []types.Rule // no rules -> no issues
 	var out bytes.Buffer
-	code := runner.Lint(".", src, rules, &out, "compact", true)
+	code := runner.Lint(".", src, rules, nil, &out, []runner.Output{{Format: "compact", Emojis: true}}, formatter.RunInfo{}, runner.BaselineOptions{}, runner.FailPolicy{})
 	if code != 0 {
 		t.Fatalf("want 0, got %d", code)
 	}
@@ -29,7 +35,7 @@ func TestRunLint_Issues(t *testing.T) {
 		RuleID: "test.always.flag", Message: "failed", Match: "", // always emits
 	}}
 	var out bytes.Buffer
-	code := runner.Lint(".", src, rules, &out, "compact", true)
+	code := runner.Lint(".", src, rules, nil, &out, []runner.Output{{Format: "compact", Emojis: true}}, formatter.RunInfo{}, runner.BaselineOptions{}, runner.FailPolicy{})
 	if code != 1 {
 		t.Fatalf("want 1, got %d", code)
 	}
@@ -40,3 +46,282 @@ func TestRunLint_Issues(t *testing.T) {
 		t.Fatalf("missing id")
 	}
 }
+
+func TestRunLint_MultipleOutputs(t *testing.T) {
+	src := testutil.MemSource{Files: map[string]string{"bad.tf": `resource "x" "y" {}`}}
+	rules := []types.Rule{&testutil.AlwaysFlag{RuleID: "test.always.flag", Message: "failed"}}
+	reportPath := filepath.Join(t.TempDir(), "tfcoach.json")
+
+	var out bytes.Buffer
+	code := runner.Lint(".", src, rules, nil, &out, []runner.Output{
+		{Format: "compact", Color: true},
+		{Format: "json", Path: reportPath, Color: true},
+	}, formatter.RunInfo{}, runner.BaselineOptions{}, runner.FailPolicy{})
+	if code != 1 {
+		t.Fatalf("want 1, got %d", code)
+	}
+	if !strings.Contains(out.String(), "Summary: 1 issue") {
+		t.Fatalf("missing compact summary on stdout: %q", out.String())
+	}
+
+	report, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("report not written: %v", err)
+	}
+	if !strings.Contains(string(report), `"issue_count": 1`) {
+		t.Fatalf("unexpected report content: %s", report)
+	}
+	if strings.Contains(string(report), "\x1b[") {
+		t.Fatalf("report file contains ANSI escape codes: %q", report)
+	}
+}
+
+func TestRunLint_FileOutputWrittenWithoutIssues(t *testing.T) {
+	src := testutil.MemSource{Files: map[string]string{"ok.tf": `# nothing`}}
+	reportPath := filepath.Join(t.TempDir(), "tfcoach.json")
+
+	var out bytes.Buffer
+	code := runner.Lint(".", src, nil, nil, &out, []runner.Output{
+		{Format: "compact"},
+		{Format: "json", Path: reportPath},
+	}, formatter.RunInfo{}, runner.BaselineOptions{}, runner.FailPolicy{})
+	if code != 0 {
+		t.Fatalf("want 0, got %d", code)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+	if _, err := os.Stat(reportPath); err != nil {
+		t.Fatalf("report not written: %v", err)
+	}
+}
+
+func TestRunLint_UnwritableOutput(t *testing.T) {
+	src := testutil.MemSource{Files: map[string]string{"bad.tf": `resource "x" "y" {}`}}
+	rules := []types.Rule{&testutil.AlwaysFlag{RuleID: "test.always.flag", Message: "failed"}}
+	reportPath := filepath.Join(t.TempDir(), "missing", "tfcoach.json")
+
+	var out bytes.Buffer
+	code := runner.Lint(".", src, rules, nil, &out, []runner.Output{{Format: "json", Path: reportPath}}, formatter.RunInfo{}, runner.BaselineOptions{}, runner.FailPolicy{})
+	if code != 2 {
+		t.Fatalf("want 2, got %d", code)
+	}
+}
+
+func TestRunLint_ReportContainsRunMetadata(t *testing.T) {
+	src := testutil.MemSource{Files: map[string]string{"bad.tf": `resource "x" "y" {}`, "ok.tf": `# nothing`}}
+	rules := []types.Rule{&testutil.AlwaysFlag{RuleID: "test.always.flag", Message: "failed"}}
+	reportPath := filepath.Join(t.TempDir(), "tfcoach.json")
+
+	var out bytes.Buffer
+	runInfo := formatter.RunInfo{BuildVersion: "v1.2.3", BuildCommit: "abc123", Config: map[string]any{"k": "v"}}
+	code := runner.Lint(".", src, rules, nil, &out, []runner.Output{{Format: "json", Path: reportPath}}, runInfo, runner.BaselineOptions{}, runner.FailPolicy{})
+	if code != 1 {
+		t.Fatalf("want 1, got %d", code)
+	}
+
+	content, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("report not written: %v", err)
+	}
+	var report struct {
+		SchemaVersion int `json:"schema_version"`
+		Tfcoach       struct {
+			Version string `json:"version"`
+			Commit  string `json:"commit"`
+		} `json:"tfcoach"`
+		Run struct {
+			Root      string `json:"root"`
+			FileCount int    `json:"file_count"`
+			Rules     []struct {
+				ID      string `json:"id"`
+				Enabled bool   `json:"enabled"`
+			} `json:"rules"`
+			Config map[string]any `json:"config"`
+		} `json:"run"`
+	}
+	if err = json.Unmarshal(content, &report); err != nil {
+		t.Fatalf("invalid report: %v", err)
+	}
+
+	if report.SchemaVersion != 1 {
+		t.Errorf("schema_version = %d, want 1", report.SchemaVersion)
+	}
+	if report.Tfcoach.Version != "v1.2.3" || report.Tfcoach.Commit != "abc123" {
+		t.Errorf("tfcoach = %+v, want v1.2.3 (abc123)", report.Tfcoach)
+	}
+	if report.Run.Root != "." || report.Run.FileCount != 2 {
+		t.Errorf("run = %+v, want root . and 2 files", report.Run)
+	}
+	if report.Run.Config["k"] != "v" {
+		t.Errorf("config = %v, want the given config", report.Run.Config)
+	}
+	for _, rule := range report.Run.Rules {
+		if rule.Enabled != (rule.ID == "test.always.flag") {
+			t.Errorf("rule %s enabled = %t", rule.ID, rule.Enabled)
+		}
+	}
+}
+
+func TestRunLint_Baseline(t *testing.T) {
+	src := testutil.MemSource{Files: map[string]string{"bad.tf": `resource "x" "y" {}`}}
+	rules := []types.Rule{&testutil.AlwaysFlag{RuleID: "test.always.flag", Message: "failed"}}
+	baselinePath := filepath.Join(t.TempDir(), "baseline.json")
+
+	var out bytes.Buffer
+	if code := runner.CreateBaseline(".", src, rules, nil, &out, baselinePath); code != 0 {
+		t.Fatalf("want 0, got %d: %s", code, out.String())
+	}
+
+	out.Reset()
+	outputs := []runner.Output{{Format: "compact"}}
+	code := runner.Lint(".", src, rules, nil, &out, outputs, formatter.RunInfo{}, runner.BaselineOptions{Path: baselinePath}, runner.FailPolicy{})
+	if code != 0 {
+		t.Fatalf("want 0 with all issues in the baseline, got %d: %s", code, out.String())
+	}
+
+	fixedSrc := testutil.MemSource{Files: map[string]string{"bad.tf": `# fixed`}}
+	baselineOptions := runner.BaselineOptions{Path: baselinePath, Prune: true}
+	if code = runner.Lint(".", fixedSrc, rules, nil, &out, outputs, formatter.RunInfo{}, baselineOptions, runner.FailPolicy{}); code != 0 {
+		t.Fatalf("want 0, got %d", code)
+	}
+	content, err := os.ReadFile(baselinePath)
+	if err != nil {
+		t.Fatalf("baseline not readable: %v", err)
+	}
+	if strings.Contains(string(content), "test.always.flag") {
+		t.Fatalf("fixed issue not pruned from baseline: %s", content)
+	}
+}
+
+func TestRunLint_BaselineWithOtherRootPath(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.MkdirAll(filepath.Join(dir, "infra"), 0o755); err != nil {
+		t.Fatalf("Setup error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "infra", "main.tf"), []byte(`resource "x" "y" {}`), 0o644); err != nil {
+		t.Fatalf("Setup error: %v", err)
+	}
+	rules := []types.Rule{&testutil.AlwaysFlag{RuleID: "test.always.flag", Message: "failed"}}
+	baselinePath := filepath.Join(dir, "baseline.json")
+
+	var out bytes.Buffer
+	if code := runner.CreateBaseline("./infra", engine.FileSystem{}, rules, nil, &out, baselinePath); code != 0 {
+		t.Fatalf("want 0, got %d: %s", code, out.String())
+	}
+
+	out.Reset()
+	outputs := []runner.Output{{Format: "compact"}}
+	baselineOptions := runner.BaselineOptions{Path: baselinePath}
+	code := runner.Lint(filepath.Join(dir, "infra"), engine.FileSystem{}, rules, nil, &out, outputs, formatter.RunInfo{}, baselineOptions, runner.FailPolicy{})
+	if code != 0 {
+		t.Fatalf("want 0 with the issue in the baseline of ./infra, got %d: %s", code, out.String())
+	}
+}
+
+func TestRunLint_MissingBaseline(t *testing.T) {
+	src := testutil.MemSource{Files: map[string]string{"ok.tf": `# nothing`}}
+	baselineOptions := runner.BaselineOptions{Path: filepath.Join(t.TempDir(), "missing.json")}
+
+	var out bytes.Buffer
+	code := runner.Lint(".", src, nil, nil, &out, []runner.Output{{Format: "compact"}}, formatter.RunInfo{}, baselineOptions, runner.FailPolicy{})
+	if code != 2 {
+		t.Fatalf("want 2, got %d", code)
+	}
+}
+
+func TestRunLint_FailPolicy(t *testing.T) {
+	src := testutil.MemSource{Files: map[string]string{"a.tf": `resource "x" "a" {}
+resource "x" "b" {}
+`}}
+	lowRule := &testutil.AlwaysFlag{RuleID: "test.low", Message: "low", Severity: constants.SeverityLow}
+	mediumRule := &testutil.AlwaysFlag{RuleID: "test.medium", Message: "medium", Severity: constants.SeverityMedium}
+
+	tests := []struct {
+		name       string
+		src        testutil.MemSource
+		failPolicy runner.FailPolicy
+		want       int
+	}{
+		{name: "default fails on any issue", src: src, failPolicy: runner.FailPolicy{}, want: 1},
+		{name: "info", src: src, failPolicy: runner.FailPolicy{FailOn: "info"}, want: 1},
+		{name: "low", src: src, failPolicy: runner.FailPolicy{FailOn: "low"}, want: 1},
+		{name: "medium", src: src, failPolicy: runner.FailPolicy{FailOn: "medium"}, want: 1},
+		{name: "high", src: src, failPolicy: runner.FailPolicy{FailOn: "high"}, want: 0},
+		{name: "never", src: src, failPolicy: runner.FailPolicy{FailOn: "never"}, want: 0},
+		{name: "below max issues", src: src, failPolicy: runner.FailPolicy{FailOn: "medium", MaxIssues: 2}, want: 0},
+		{name: "above max issues", src: src, failPolicy: runner.FailPolicy{FailOn: "low", MaxIssues: 3}, want: 1},
+		{
+			name:       "parse errors fail",
+			src:        testutil.MemSource{Files: map[string]string{"broken.tf": `x`}},
+			failPolicy: runner.FailPolicy{FailOn: "high"},
+			want:       1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			code := runner.Lint(
+				".",
+				tt.src,
+				[]types.Rule{lowRule, mediumRule},
+				nil,
+				&out,
+				[]runner.Output{{Format: "compact"}},
+				formatter.RunInfo{},
+				runner.BaselineOptions{},
+				tt.failPolicy,
+			)
+			if code != tt.want {
+				t.Errorf("want %d, got %d", tt.want, code)
+			}
+			if !strings.Contains(out.String(), "Summary:") {
+				t.Errorf("issues have to be reported regardless of the exit code, got %q", out.String())
+			}
+		})
+	}
+}
+
+func TestRunLint_MachineReadableStdoutWithoutIssues(t *testing.T) {
+	src := testutil.MemSource{Files: map[string]string{"ok.tf": `# nothing`}}
+	tests := []struct {
+		format    string
+		wantEmpty bool
+	}{
+		{format: "compact", wantEmpty: true},
+		{format: "pretty", wantEmpty: true},
+		{format: "educational", wantEmpty: true},
+		{format: "json"},
+		{format: "sarif"},
+		{format: "junit"},
+		{format: "gitlab"},
+		{format: "checkstyle"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var out bytes.Buffer
+			code := runner.Lint(".", src, nil, nil, &out, []runner.Output{{Format: tt.format}}, formatter.RunInfo{}, runner.BaselineOptions{}, runner.FailPolicy{})
+			if code != 0 {
+				t.Fatalf("want 0, got %d", code)
+			}
+			if (out.Len() == 0) != tt.wantEmpty {
+				t.Errorf("output = %q, want empty %v", out.String(), tt.wantEmpty)
+			}
+		})
+	}
+}
+
+func TestRunLint_JUnitStdoutWithoutIssues(t *testing.T) {
+	src := testutil.MemSource{Files: map[string]string{"ok.tf": `# nothing`}}
+	var out bytes.Buffer
+	code := runner.Lint(".", src, nil, nil, &out, []runner.Output{{Format: "junit"}}, formatter.RunInfo{}, runner.BaselineOptions{}, runner.FailPolicy{})
+	if code != 0 {
+		t.Fatalf("want 0, got %d", code)
+	}
+	for _, want := range []string{`<testsuites name="tfcoach" tests="1" failures="0">`, `<testcase name="no issues found" classname="ok.tf">`} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out.String())
+		}
+	}
+}
@@ -8,7 +8,7 @@ import (
 	"github.com/Marcel2603/tfcoach/internal/formatter"
 )
 
-func Print(srcReportPath string, w io.Writer, outputFormat string, allowEmojis bool) int {
+func Print(srcReportPath string, w io.Writer, outputFormat string, options formatter.Options) int {
 	var reportContent []byte
 	var err error
 	if srcReportPath == "-" {
@@ -21,7 +21,7 @@ func Print(srcReportPath string, w io.Writer, outputFormat string, allowEmojis b
 		return 1
 	}
 
-	err = formatter.ReformatResults(reportContent, w, outputFormat, allowEmojis)
+	err = formatter.ReformatResults(reportContent, w, outputFormat, options)
 	if err != nil {
 		_, _ = fmt.Fprintf(w, "failed to convert report: %v", err)
 		return 2
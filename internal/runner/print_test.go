@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/Marcel2603/tfcoach/internal/formatter"
 )
 
 func TestRunPrint(t *testing.T) {
@@ -56,7 +58,7 @@ func TestRunPrint(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			w := &bytes.Buffer{}
-			returnCode := Print(tt.args.srcReportPath, w, tt.args.outputFormat, tt.args.allowEmojis)
+			returnCode := Print(tt.args.srcReportPath, w, tt.args.outputFormat, formatter.Options{AllowEmojis: tt.args.allowEmojis})
 			if returnCode != tt.want {
 				t.Errorf("Print() = %v, want %v", returnCode, tt.want)
 			}
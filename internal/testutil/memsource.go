@@ -6,6 +6,8 @@ import "github.com/Marcel2603/tfcoach/internal/engine"
 
 type MemSource struct {
 	Files map[string]string
+	// ConfigFiles are listed as found configuration files, their content is not read
+	ConfigFiles []string
 }
 
 func (m MemSource) List(_ string) (*engine.FileList, error) {
@@ -13,7 +15,7 @@ func (m MemSource) List(_ string) (*engine.FileList, error) {
 	for p := range m.Files {
 		paths = append(paths, p)
 	}
-	return &engine.FileList{TerraformFiles: paths}, nil
+	return &engine.FileList{TerraformFiles: paths, ConfigFiles: m.ConfigFiles}, nil
 }
 
 func (m MemSource) ReadFile(path string) ([]byte, error) {
@@ -4,6 +4,7 @@ package testutil
 
 import (
 	"strings"
+	"testing"
 
 	"github.com/Marcel2603/tfcoach/internal/constants"
 	"github.com/Marcel2603/tfcoach/internal/types"
@@ -15,15 +16,21 @@ type AlwaysFlag struct {
 	RuleID  string
 	Message string
 	Match   string
+	// Severity defaults to high
+	Severity types.Severity
 }
 
 func (r *AlwaysFlag) ID() string { return r.RuleID }
 
 func (r *AlwaysFlag) META() types.RuleMeta {
+	severity := r.Severity
+	if severity == (types.Severity{}) {
+		severity = constants.SeverityHigh
+	}
 	return types.RuleMeta{
 		Title:       "AlwaysFlag",
 		Description: r.Message,
-		Severity:    constants.SeverityHigh,
+		Severity:    severity,
 		DocsURI:     "tbd",
 	}
 }
@@ -112,3 +119,13 @@ func (r *FlagOnFinish) Finish() []types.Issue {
 		RuleID:  r.RuleID,
 	}}
 }
+
+// RuleWithSpec returns the rule configured with spec, the way a rule entry of the configuration does.
+func RuleWithSpec(t *testing.T, rule types.ConfigurableRule, spec map[string]any) types.Rule {
+	t.Helper()
+	options, err := types.ParseRuleOptions(rule.Options(), spec)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return rule.WithOptions(options)
+}
@@ -8,4 +8,6 @@ type Issue struct {
 	Range   hcl.Range
 	Message string
 	RuleID  string
+	// Details holds rule specific data for machine-readable reports, e.g. the expected order of parameters.
+	Details map[string]any
 }
@@ -0,0 +1,245 @@
+//revive:disable:var-naming For now it's okay to have a generic name
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+)
+
+type RuleOptionType string
+
+const (
+	RuleOptionString     RuleOptionType = "string"
+	RuleOptionNumber     RuleOptionType = "number"
+	RuleOptionBool       RuleOptionType = "bool"
+	RuleOptionStringList RuleOptionType = "list(string)"
+	RuleOptionStringMap  RuleOptionType = "map(string)"
+	// RuleOptionStringListMap maps keys to lists of strings, e.g. block types to file names.
+	RuleOptionStringListMap RuleOptionType = "map(list(string))"
+)
+
+// RuleOption declares an option that can be set under rules.<rule_id>.spec. Default must have the Go type of the
+// option: string, int, bool, []string, map[string]string or map[string][]string.
+type RuleOption struct {
+	Name        string
+	Type        RuleOptionType
+	Default     any
+	Description string
+	// Allowed restricts string options to the given values.
+	Allowed []string
+	// Validate optionally checks the parsed value beyond its type, e.g. that a string is a valid regex.
+	Validate func(value any) error
+}
+
+// ConfigurableRule is implemented by rules that accept options.
+type ConfigurableRule interface {
+	Rule
+	Options() []RuleOption
+	// WithOptions returns a new instance of the rule that uses the given options.
+	WithOptions(options RuleOptions) Rule
+}
+
+// RuleOptions holds the parsed options of a rule, with defaults for the ones that are not configured.
+type RuleOptions map[string]any
+
+// ParseRuleOptions checks spec against the declared options and converts its values to their Go types.
+func ParseRuleOptions(declared []RuleOption, spec map[string]any) (RuleOptions, error) {
+	declaredByName := make(map[string]RuleOption, len(declared))
+	options := make(RuleOptions, len(declared))
+	for _, option := range declared {
+		declaredByName[option.Name] = option
+		options[option.Name] = cloneOptionValue(option.Default)
+	}
+
+	var errs []string
+	for _, name := range slices.Sorted(maps.Keys(spec)) {
+		option, ok := declaredByName[name]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("unknown option %q (supported: %s)", name, supportedOptionNames(declared)))
+			continue
+		}
+		value, err := parseOptionValue(option, spec[name])
+		if err == nil && option.Validate != nil {
+			err = option.Validate(value)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("invalid option %q: %s", name, err))
+			continue
+		}
+		options[name] = value
+	}
+	if len(errs) > 0 {
+		return nil, errors.New(strings.Join(errs, "; "))
+	}
+	return options, nil
+}
+
+func (o RuleOptions) String(name string) string {
+	value, _ := o[name].(string)
+	return value
+}
+
+func (o RuleOptions) Int(name string) int {
+	value, _ := o[name].(int)
+	return value
+}
+
+func (o RuleOptions) Bool(name string) bool {
+	value, _ := o[name].(bool)
+	return value
+}
+
+func (o RuleOptions) StringList(name string) []string {
+	value, _ := o[name].([]string)
+	return value
+}
+
+func (o RuleOptions) StringMap(name string) map[string]string {
+	value, _ := o[name].(map[string]string)
+	return value
+}
+
+func (o RuleOptions) StringListMap(name string) map[string][]string {
+	value, _ := o[name].(map[string][]string)
+	return value
+}
+
+// DefaultString renders the default value for documentation.
+func (o RuleOption) DefaultString() string {
+	if o.Default == nil {
+		return "-"
+	}
+	if s, ok := o.Default.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(o.Default)
+	if err != nil {
+		return fmt.Sprint(o.Default)
+	}
+	return string(data)
+}
+
+func parseOptionValue(option RuleOption, raw any) (any, error) {
+	switch option.Type {
+	case RuleOptionString:
+		value, ok := raw.(string)
+		if !ok {
+			return nil, typeError(option, raw)
+		}
+		if len(option.Allowed) > 0 && !slices.Contains(option.Allowed, value) {
+			return nil, fmt.Errorf("%q is not one of %s", value, strings.Join(option.Allowed, "|"))
+		}
+		return value, nil
+	case RuleOptionNumber:
+		switch value := raw.(type) {
+		case int:
+			return value, nil
+		case int64:
+			return int(value), nil
+		case uint64:
+			return int(value), nil
+		case float64:
+			// JSON numbers
+			if value == math.Trunc(value) {
+				return int(value), nil
+			}
+		}
+		return nil, typeError(option, raw)
+	case RuleOptionBool:
+		value, ok := raw.(bool)
+		if !ok {
+			return nil, typeError(option, raw)
+		}
+		return value, nil
+	case RuleOptionStringList:
+		values, ok := parseStringList(raw)
+		if !ok {
+			return nil, typeError(option, raw)
+		}
+		return values, nil
+	case RuleOptionStringMap:
+		entries, ok := raw.(map[string]any)
+		if !ok {
+			return nil, typeError(option, raw)
+		}
+		values := make(map[string]string, len(entries))
+		for key, entry := range entries {
+			value, isString := entry.(string)
+			if !isString {
+				return nil, typeError(option, raw)
+			}
+			values[key] = value
+		}
+		return values, nil
+	case RuleOptionStringListMap:
+		entries, ok := raw.(map[string]any)
+		if !ok {
+			return nil, typeError(option, raw)
+		}
+		values := make(map[string][]string, len(entries))
+		for key, entry := range entries {
+			value, isList := parseStringList(entry)
+			if !isList {
+				return nil, typeError(option, raw)
+			}
+			values[key] = value
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unsupported option type %q", option.Type)
+	}
+}
+
+func parseStringList(raw any) ([]string, bool) {
+	items, ok := raw.([]any)
+	if !ok {
+		return nil, false
+	}
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		value, isString := item.(string)
+		if !isString {
+			return nil, false
+		}
+		values = append(values, value)
+	}
+	return values, true
+}
+
+func typeError(option RuleOption, raw any) error {
+	return fmt.Errorf("expected %s, got %v", option.Type, raw)
+}
+
+func supportedOptionNames(declared []RuleOption) string {
+	if len(declared) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(declared))
+	for _, option := range declared {
+		names = append(names, option.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// cloneOptionValue copies slices and maps, so that rules cannot modify the declared defaults.
+func cloneOptionValue(value any) any {
+	switch v := value.(type) {
+	case []string:
+		return slices.Clone(v)
+	case map[string]string:
+		return maps.Clone(v)
+	case map[string][]string:
+		cloned := make(map[string][]string, len(v))
+		for key, values := range v {
+			cloned[key] = slices.Clone(values)
+		}
+		return cloned
+	default:
+		return value
+	}
+}
@@ -0,0 +1,122 @@
+package types_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Marcel2603/tfcoach/internal/types"
+)
+
+var testOptions = []types.RuleOption{
+	{Name: "style", Type: types.RuleOptionString, Default: "snake", Allowed: []string{"snake", "kebab"}},
+	{Name: "max_length", Type: types.RuleOptionNumber, Default: 64},
+	{Name: "strict", Type: types.RuleOptionBool, Default: false},
+	{Name: "order", Type: types.RuleOptionStringList, Default: []string{"a", "b"}},
+	{Name: "patterns", Type: types.RuleOptionStringMap, Default: map[string]string{}},
+	{Name: "files", Type: types.RuleOptionStringListMap, Default: map[string][]string{"output": {"outputs.tf"}}},
+}
+
+func TestParseRuleOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		spec map[string]any
+		want types.RuleOptions
+	}{
+		{
+			name: "defaults",
+			spec: nil,
+			want: types.RuleOptions{
+				"style":      "snake",
+				"max_length": 64,
+				"strict":     false,
+				"order":      []string{"a", "b"},
+				"patterns":   map[string]string{},
+				"files":      map[string][]string{"output": {"outputs.tf"}},
+			},
+		},
+		{
+			name: "all set",
+			spec: map[string]any{
+				"style":      "kebab",
+				"max_length": float64(32),
+				"strict":     true,
+				"order":      []any{"b", "a"},
+				"patterns":   map[string]any{"resource": "^[a-z]+$"},
+				"files":      map[string]any{"variable": []any{"variables*.tf"}},
+			},
+			want: types.RuleOptions{
+				"style":      "kebab",
+				"max_length": 32,
+				"strict":     true,
+				"order":      []string{"b", "a"},
+				"patterns":   map[string]string{"resource": "^[a-z]+$"},
+				"files":      map[string][]string{"variable": {"variables*.tf"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := types.ParseRuleOptions(testOptions, tt.spec)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRuleOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRuleOptions_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    map[string]any
+		wantErr string
+	}{
+		{name: "unknown option", spec: map[string]any{"colour": "red"}, wantErr: `unknown option "colour"`},
+		{name: "not allowed", spec: map[string]any{"style": "camel"}, wantErr: `"camel" is not one of snake|kebab`},
+		{name: "string instead of number", spec: map[string]any{"max_length": "64"}, wantErr: "expected number"},
+		{name: "fraction", spec: map[string]any{"max_length": 1.5}, wantErr: "expected number"},
+		{name: "string instead of bool", spec: map[string]any{"strict": "yes"}, wantErr: "expected bool"},
+		{name: "list of numbers", spec: map[string]any{"order": []any{1, 2}}, wantErr: "expected list(string)"},
+		{name: "string instead of map", spec: map[string]any{"patterns": "x"}, wantErr: "expected map(string)"},
+		{name: "string in map of lists", spec: map[string]any{"files": map[string]any{"output": "x"}}, wantErr: "expected map(list(string))"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := types.ParseRuleOptions(testOptions, tt.spec)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseRuleOptions() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseRuleOptions_DefaultsAreCopied(t *testing.T) {
+	options, _ := types.ParseRuleOptions(testOptions, nil)
+	options.StringList("order")[0] = "changed"
+
+	if testOptions[3].Default.([]string)[0] != "a" {
+		t.Errorf("Modifying parsed options changed the declared default")
+	}
+}
+
+func TestRuleOption_DefaultString(t *testing.T) {
+	tests := []struct {
+		option types.RuleOption
+		want   string
+	}{
+		{option: testOptions[0], want: "snake"},
+		{option: testOptions[1], want: "64"},
+		{option: testOptions[3], want: `["a","b"]`},
+		{option: types.RuleOption{Name: "none"}, want: "-"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.option.Name, func(t *testing.T) {
+			if got := tt.option.DefaultString(); got != tt.want {
+				t.Errorf("DefaultString() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
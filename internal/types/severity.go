@@ -28,6 +28,8 @@ func (s Severity) Color() color.Attribute {
 		return color.FgHiYellow
 	case 3:
 		return color.FgHiWhite
+	case 4:
+		return color.FgCyan
 	default:
 		return color.Reset
 	}
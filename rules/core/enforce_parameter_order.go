@@ -3,7 +3,7 @@ package core
 import (
 	"cmp"
 	"fmt"
-	"log/slog"
+	"maps"
 	"slices"
 	"strings"
 
@@ -13,14 +13,26 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
+const (
+	parameterOrderOptionCategoryOrder = "category_order"
+	parameterOrderOptionBlockOrders   = "block_orders"
+	parameterOrderOptionBlockTypes    = "block_types"
+
+	// categories of all attributes and blocks that are not listed by name
+	nonBlockCategory = "non_block"
+	blockCategory    = "block"
+	// separates categories of the same rank, e.g. "count|for_each"
+	categoryRankSeparator = "|"
+)
+
 var (
-	categoryOrder = map[string]int{
-		"count":      0,
-		"for_each":   0,
-		"non_block":  10,
-		"block":      20,
-		"lifecycle":  30,
-		"depends_on": 40,
+	// categoryOrder follows https://developer.hashicorp.com/terraform/language/style#resource-order
+	categoryOrder = []string{
+		"count|for_each",
+		nonBlockCategory,
+		blockCategory,
+		"lifecycle",
+		"depends_on",
 	}
 	supportedBlocks = []string{
 		"resource",
@@ -33,24 +45,53 @@ var (
 )
 
 type detectedParam struct {
+	name      string
 	paramType string
-	startPos  hcl.Pos
+	rank      int
+	hclRange  hcl.Range
 }
 
 func (d detectedParam) compare(other detectedParam) int {
-	if d.startPos.Line != other.startPos.Line {
-		return cmp.Compare(d.startPos.Line, other.startPos.Line)
+	if d.hclRange.Start.Line != other.hclRange.Start.Line {
+		return cmp.Compare(d.hclRange.Start.Line, other.hclRange.Start.Line)
 	}
-	return cmp.Compare(d.startPos.Column, other.startPos.Column)
+	return cmp.Compare(d.hclRange.Start.Column, other.hclRange.Start.Column)
+}
+
+// misplacedParam is a parameter that has to move before or after anchor.
+type misplacedParam struct {
+	param  detectedParam
+	anchor detectedParam
+	before bool
 }
 
 type EnforceParameterOrder struct {
-	id string
+	id         string
+	blockTypes []string
+	// rank of each category, by block type; block types without an entry use defaultRanks
+	defaultRanks map[string]int
+	blockRanks   map[string]map[string]int
 }
 
 func EnforceParameterOrderRule() *EnforceParameterOrder {
+	return newEnforceParameterOrder(defaultOptionsOf(&EnforceParameterOrder{}))
+}
+
+func newEnforceParameterOrder(options types.RuleOptions) *EnforceParameterOrder {
+	// options have been validated already
+	defaultRanks, _ := rankCategories(options.StringList(parameterOrderOptionCategoryOrder))
+	blockRanks := make(map[string]map[string]int)
+	for blockType, order := range options.StringListMap(parameterOrderOptionBlockOrders) {
+		if ranks, err := rankCategories(order); err == nil {
+			blockRanks[blockType] = ranks
+		}
+	}
+
 	return &EnforceParameterOrder{
-		id: rulePrefix + ".enforce_parameter_order",
+		id:           rulePrefix + ".enforce_parameter_order",
+		blockTypes:   options.StringList(parameterOrderOptionBlockTypes),
+		defaultRanks: defaultRanks,
+		blockRanks:   blockRanks,
 	}
 }
 
@@ -67,6 +108,48 @@ func (e *EnforceParameterOrder) META() types.RuleMeta {
 	}
 }
 
+func (*EnforceParameterOrder) Options() []types.RuleOption {
+	return []types.RuleOption{
+		{
+			Name:    parameterOrderOptionCategoryOrder,
+			Type:    types.RuleOptionStringList,
+			Default: categoryOrder,
+			Description: "Order of the parameters: names of attributes or blocks, \"non_block\" and \"block\" for all others; " +
+				"\"|\" joins categories of the same rank",
+			Validate: func(value any) error {
+				order, _ := value.([]string)
+				_, err := rankCategories(order)
+				return err
+			},
+		},
+		{
+			Name:        parameterOrderOptionBlockOrders,
+			Type:        types.RuleOptionStringListMap,
+			Default:     map[string][]string{},
+			Description: "Order of the parameters per block type, replacing category_order for that block type",
+			Validate: func(value any) error {
+				orders, _ := value.(map[string][]string)
+				for _, blockType := range slices.Sorted(maps.Keys(orders)) {
+					if _, err := rankCategories(orders[blockType]); err != nil {
+						return fmt.Errorf("%s: %w", blockType, err)
+					}
+				}
+				return nil
+			},
+		},
+		{
+			Name:        parameterOrderOptionBlockTypes,
+			Type:        types.RuleOptionStringList,
+			Default:     supportedBlocks,
+			Description: "Block types to check, besides the ones in block_orders",
+		},
+	}
+}
+
+func (*EnforceParameterOrder) WithOptions(options types.RuleOptions) types.Rule {
+	return newEnforceParameterOrder(options)
+}
+
 func (e *EnforceParameterOrder) Apply(path string, f *hcl.File) []types.Issue {
 	body, ok := f.Body.(*hclsyntax.Body)
 	if !ok {
@@ -74,15 +157,34 @@ func (e *EnforceParameterOrder) Apply(path string, f *hcl.File) []types.Issue {
 	}
 	var out []types.Issue
 	for _, blk := range body.Blocks {
-		if slices.Contains(supportedBlocks, blk.Type) {
-			if !isParameterOrderCorrect(blk.Body) {
-				out = append(out, types.Issue{
-					File:    path,
-					Range:   blk.Range(),
-					Message: fmt.Sprintf("Parameter order in %s block \"%s\" is incorrect", blk.Type, nameOf(blk)),
-					RuleID:  e.id,
-				})
+		ranks, configured := e.blockRanks[blk.Type]
+		if !configured {
+			ranks = e.defaultRanks
+		}
+		if !configured && !slices.Contains(e.blockTypes, blk.Type) {
+			continue
+		}
+
+		params := detectParams(blk.Body, ranks)
+		expectedOrder := expectedOrderOf(params)
+		for _, misplaced := range findMisplacedParams(params) {
+			direction := "after"
+			if misplaced.before {
+				direction = "before"
 			}
+			out = append(out, types.Issue{
+				File:  path,
+				Range: misplaced.param.hclRange,
+				Message: fmt.Sprintf(
+					"`%s` must come %s `%s` in %s block \"%s\"",
+					misplaced.param.name, direction, misplaced.anchor.name, blk.Type, nameOf(blk),
+				),
+				RuleID: e.id,
+				Details: map[string]any{
+					"parameter":      misplaced.param.name,
+					"expected_order": expectedOrder,
+				},
+			})
 		}
 	}
 	return out
@@ -92,68 +194,147 @@ func (*EnforceParameterOrder) Finish() []types.Issue {
 	return []types.Issue{}
 }
 
-func isParameterOrderCorrect(body *hclsyntax.Body) bool {
-	// detect parameters in all attributes and blocks
+// detectParams returns all attributes and blocks of body ordered by their position in the file.
+func detectParams(body *hclsyntax.Body, ranks map[string]int) []detectedParam {
 	var detectedParams []detectedParam
 	for _, attr := range body.Attributes {
-		detectedParams = append(detectedParams, detectFromAttribute(attr))
+		detectedParams = append(detectedParams, detectFromAttribute(attr, ranks))
 	}
 	for _, blk := range body.Blocks {
-		detectedParams = append(detectedParams, detectFromBlock(blk))
+		detectedParams = append(detectedParams, detectFromBlock(blk, ranks))
 	}
 
-	// order detected parameters by their position in the file
 	slices.SortStableFunc(detectedParams, func(a, b detectedParam) int { return a.compare(b) })
+	return detectedParams
+}
 
-	// assign expected order to each parameter
-	var foundCategories []int
-	for _, param := range detectedParams {
-		order, ok := categoryOrder[param.paramType]
-		if ok {
-			foundCategories = append(foundCategories, order)
+// findMisplacedParams keeps the longest sequence of parameters that is already in order and reports all others, so
+// that moving as few parameters as possible fixes the order.
+func findMisplacedParams(params []detectedParam) []misplacedParam {
+	// longest non-decreasing subsequence of the ranks: tails[i] is the index of the last parameter of the best
+	// sequence of length i+1, previous links each parameter to its predecessor in that sequence
+	var tails []int
+	previous := make([]int, len(params))
+	for i, param := range params {
+		position, _ := slices.BinarySearchFunc(tails, param.rank, func(tail int, rank int) int {
+			if params[tail].rank <= rank {
+				return -1
+			}
+			return 1
+		})
+		previous[i] = -1
+		if position > 0 {
+			previous[i] = tails[position-1]
+		}
+		if position == len(tails) {
+			tails = append(tails, i)
 		} else {
-			slog.Warn("category not found", "type", param.paramType)
+			tails[position] = i
 		}
 	}
 
-	// check if the list of categories in order of appearance is correctly sorted
-	previous := 0
-	for _, foundCategory := range foundCategories {
-		if foundCategory < previous {
-			return false
+	inOrder := make([]bool, len(params))
+	if len(tails) > 0 {
+		for i := tails[len(tails)-1]; i >= 0; i = previous[i] {
+			inOrder[i] = true
 		}
-		previous = foundCategory
 	}
 
-	return true
+	var misplaced []misplacedParam
+	for i, param := range params {
+		if inOrder[i] {
+			continue
+		}
+		if anchor, found := firstInOrder(params[:i], inOrder[:i], func(rank int) bool { return rank > param.rank }); found {
+			// a parameter that ranks after this one comes too early ...
+			misplaced = append(misplaced, misplacedParam{param: param, anchor: anchor, before: true})
+		} else if anchor, found := lastInOrder(params[i+1:], inOrder[i+1:], func(rank int) bool { return rank < param.rank }); found {
+			// ... or one that ranks before it comes too late
+			misplaced = append(misplaced, misplacedParam{param: param, anchor: anchor, before: false})
+		}
+	}
+	return misplaced
 }
 
-func detectFromAttribute(attr *hclsyntax.Attribute) detectedParam {
-	var paramType string
-	switch attr.Name {
-	case "count", "for_each", "depends_on":
-		paramType = attr.Name
-	default:
-		paramType = "non_block"
+func firstInOrder(params []detectedParam, inOrder []bool, matches func(rank int) bool) (detectedParam, bool) {
+	for i, param := range params {
+		if inOrder[i] && matches(param.rank) {
+			return param, true
+		}
 	}
+	return detectedParam{}, false
+}
 
-	return detectedParam{
-		paramType: paramType,
-		startPos:  attr.Range().Start,
+func lastInOrder(params []detectedParam, inOrder []bool, matches func(rank int) bool) (detectedParam, bool) {
+	for i := len(params) - 1; i >= 0; i-- {
+		if inOrder[i] && matches(params[i].rank) {
+			return params[i], true
+		}
+	}
+	return detectedParam{}, false
+}
+
+func expectedOrderOf(params []detectedParam) []string {
+	sorted := slices.Clone(params)
+	slices.SortStableFunc(sorted, func(a, b detectedParam) int { return cmp.Compare(a.rank, b.rank) })
+	names := make([]string, 0, len(sorted))
+	for _, param := range sorted {
+		names = append(names, param.name)
 	}
+	return names
 }
 
-func detectFromBlock(blk *hclsyntax.Block) detectedParam {
-	var paramType string
-	switch blk.Type {
-	case "lifecycle":
-		paramType = blk.Type
-	default:
-		paramType = "block"
+func detectFromAttribute(attr *hclsyntax.Attribute, ranks map[string]int) detectedParam {
+	paramType := categoryOf(attr.Name, nonBlockCategory, ranks)
+	return detectedParam{
+		name:      attr.Name,
+		paramType: paramType,
+		rank:      ranks[paramType],
+		hclRange:  attr.SrcRange,
 	}
+}
 
+func detectFromBlock(blk *hclsyntax.Block, ranks map[string]int) detectedParam {
+	paramType := categoryOf(blk.Type, blockCategory, ranks)
 	return detectedParam{
+		name:      blk.Type,
 		paramType: paramType,
-		startPos:  blk.Range().Start,
+		rank:      ranks[paramType],
+		// only the header, a misplaced block is usually long
+		hclRange: blk.DefRange(),
+	}
+}
+
+// categoryOf returns the category of a parameter: its own name if the order lists it, fallback otherwise.
+func categoryOf(name string, fallback string, ranks map[string]int) string {
+	if name == nonBlockCategory || name == blockCategory {
+		return fallback
+	}
+	if _, listed := ranks[name]; listed {
+		return name
+	}
+	return fallback
+}
+
+// rankCategories maps each category of order to its position. Categories joined with "|" share a position.
+func rankCategories(order []string) (map[string]int, error) {
+	ranks := make(map[string]int)
+	for rank, entry := range order {
+		for _, category := range strings.Split(entry, categoryRankSeparator) {
+			category = strings.TrimSpace(category)
+			if category == "" {
+				return nil, fmt.Errorf("empty category in %q", entry)
+			}
+			if _, duplicate := ranks[category]; duplicate {
+				return nil, fmt.Errorf("category %q is listed more than once", category)
+			}
+			ranks[category] = rank
+		}
+	}
+	for _, required := range []string{nonBlockCategory, blockCategory} {
+		if _, ok := ranks[required]; !ok {
+			return nil, fmt.Errorf("category %q is missing", required)
+		}
 	}
+	return ranks, nil
 }
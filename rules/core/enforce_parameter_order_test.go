@@ -1,6 +1,7 @@
 package core_test
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
@@ -342,7 +343,7 @@ resource "aws_instance" "non_compliant_3" {
 		},
 		{
 			"ephemeral_completely_wrong_order",
-			1,
+			2,
 			`ephemeral "aws_instance" "web" {
   lifecycle {
     ignore_changes = [tags]
@@ -431,10 +432,10 @@ variable "environment" {
 }`
 	expectedIssueCount := 4
 	expectedIssueMessages := []string{
-		"Parameter order in data block \"latest\" is incorrect",
-		"Parameter order in resource block \"web\" is incorrect",
-		"Parameter order in output block \"my_output\" is incorrect",
-		"Parameter order in variable block \"environment\" is incorrect",
+		"`lifecycle` must come after `name` in data block \"latest\"",
+		"`ami` must come after `count` in resource block \"web\"",
+		"`depends_on` must come after `sensitive` in output block \"my_output\"",
+		"`validation` must come after `default` in variable block \"environment\"",
 	}
 
 	rule := core.EnforceParameterOrderRule()
@@ -460,3 +461,155 @@ func TestEnforceParameterOrder_FinishShouldDoNothing(t *testing.T) {
 		t.Fatalf("Issues found; expected none; got %d: %#v", len(issues), issues)
 	}
 }
+
+func TestEnforceParameterOrder_Options(t *testing.T) {
+	cases := []struct {
+		name        string
+		spec        map[string]any
+		fileContent string
+		wantIssues  int
+	}{
+		{
+			name: "provider first",
+			spec: map[string]any{"category_order": []any{"provider", "count|for_each", "non_block", "block", "lifecycle", "depends_on"}},
+			fileContent: `resource "aws_instance" "web" {
+  provider = aws.west
+  count    = 1
+  ami      = 1234
+}`,
+			wantIssues: 0,
+		},
+		{
+			name: "provider not first",
+			spec: map[string]any{"category_order": []any{"provider", "count|for_each", "non_block", "block", "lifecycle", "depends_on"}},
+			fileContent: `resource "aws_instance" "web" {
+  count    = 1
+  provider = aws.west
+}`,
+			wantIssues: 1,
+		},
+		{
+			name: "tags last",
+			spec: map[string]any{"category_order": []any{"count|for_each", "non_block", "block", "tags", "lifecycle", "depends_on"}},
+			fileContent: `resource "aws_instance" "web" {
+  ami  = 1234
+  tags = {}
+  ebs_block_device {}
+}`,
+			wantIssues: 1,
+		},
+		{
+			name: "source and version first in modules",
+			spec: map[string]any{"block_orders": map[string]any{"module": []any{"source", "version", "count|for_each", "non_block", "block", "depends_on"}}},
+			fileContent: `module "vpc" {
+  count   = 1
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "1.0.0"
+}
+
+resource "aws_instance" "web" {
+  count = 1
+  ami   = 1234
+}`,
+			wantIssues: 1,
+		},
+		{
+			name: "variable order",
+			spec: map[string]any{"block_orders": map[string]any{"variable": []any{"description", "type", "default", "non_block", "block"}}},
+			fileContent: `variable "environment" {
+  type        = string
+  description = "Environment"
+}`,
+			wantIssues: 1,
+		},
+		{
+			name: "block order for an unchecked block type",
+			spec: map[string]any{"block_orders": map[string]any{"provider": []any{"alias", "non_block", "block"}}},
+			fileContent: `provider "aws" {
+  region = "eu-central-1"
+  alias  = "west"
+}`,
+			wantIssues: 1,
+		},
+		{
+			name: "block types",
+			spec: map[string]any{"block_types": []any{"data"}},
+			fileContent: `resource "aws_instance" "web" {
+  ami   = 1234
+  count = 1
+}`,
+			wantIssues: 0,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := testutil.RuleWithSpec(t, core.EnforceParameterOrderRule(), tt.spec)
+			issues := rule.Apply("a.tf", testutil.ParseToHcl(t, "a.tf", tt.fileContent))
+			if len(issues) != tt.wantIssues {
+				t.Fatalf("expected %d issues; got %d: %#v", tt.wantIssues, len(issues), issues)
+			}
+		})
+	}
+}
+
+func TestEnforceParameterOrder_InvalidOptions(t *testing.T) {
+	cases := []struct {
+		name string
+		spec map[string]any
+	}{
+		{name: "missing non_block", spec: map[string]any{"category_order": []any{"count", "block"}}},
+		{name: "missing block", spec: map[string]any{"block_orders": map[string]any{"module": []any{"source", "non_block"}}}},
+		{name: "duplicate category", spec: map[string]any{"category_order": []any{"count", "non_block", "block", "count"}}},
+		{name: "empty category", spec: map[string]any{"category_order": []any{"count|", "non_block", "block"}}},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := types.ParseRuleOptions(core.EnforceParameterOrderRule().Options(), tt.spec); err == nil {
+				t.Errorf("expected an error for %v", tt.spec)
+			}
+		})
+	}
+}
+
+func TestEnforceParameterOrder_IssuePerParameter(t *testing.T) {
+	fileContent := `resource "aws_instance" "web" {
+  ami = 1234
+  depends_on = [aws_s3_bucket.s3]
+  instance_market_options {
+    market_type = "spot"
+  }
+  lifecycle {
+    ignore_changes = [tags]
+  }
+  count = 1
+  availability_zone = "custom-az"
+}`
+	expectedOrder := []string{"count", "ami", "availability_zone", "instance_market_options", "lifecycle", "depends_on"}
+	want := []struct {
+		message string
+		line    int
+	}{
+		{"`depends_on` must come after `lifecycle` in resource block \"web\"", 3},
+		{"`count` must come before `ami` in resource block \"web\"", 10},
+		{"`availability_zone` must come before `instance_market_options` in resource block \"web\"", 11},
+	}
+
+	rule := core.EnforceParameterOrderRule()
+	issues := rule.Apply("a.tf", testutil.ParseToHcl(t, "a.tf", fileContent))
+
+	if len(issues) != len(want) {
+		t.Fatalf("Mismatch in reported issue count; want %d; got %d: %#v", len(want), len(issues), issues)
+	}
+	for idx, issue := range issues {
+		if issue.Message != want[idx].message {
+			t.Errorf("Mismatch in issue message; want '%s'; got '%s'", want[idx].message, issue.Message)
+		}
+		if issue.Range.Start.Line != want[idx].line || issue.Range.End.Line != want[idx].line {
+			t.Errorf("Mismatch in range of %s; want line %d; got %s", issue.Message, want[idx].line, issue.Range)
+		}
+		if !reflect.DeepEqual(issue.Details["expected_order"], expectedOrder) {
+			t.Errorf("Mismatch in expected order; want %v; got %v", expectedOrder, issue.Details["expected_order"])
+		}
+	}
+}
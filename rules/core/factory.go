@@ -27,16 +27,44 @@ var (
 	ruleMap = mapRules(rules)
 )
 
+func init() {
+	for _, rule := range rules {
+		config.RegisterRuleOptions(rule.ID(), optionsOf(rule))
+	}
+}
+
+// All returns every rule, configured with the options and severity overrides of the configuration.
 func All() []types.Rule {
-	return rules
+	allRules := make([]types.Rule, 0, len(rules))
+	for _, rule := range rules {
+		allRules = append(allRules, configured(rule))
+	}
+	return allRules
 }
 
 func EnabledRules() []types.Rule {
 	var enabledRules []types.Rule
 	for _, rule := range rules {
-		if config.GetConfigByRuleID(rule.ID()).Enabled {
-			enabledRules = append(enabledRules, rule)
+		if config.GetConfigByRuleID(rule.ID()).IsEnabled() {
+			enabledRules = append(enabledRules, configured(rule))
+		}
+	}
+	return enabledRules
+}
+
+// EnabledRulesFor returns the rules enabled by the given rule configurations, configured with their options and the
+// severity overrides of the configuration. Rules without options share their state with those of EnabledRules.
+// Severities can only be set in the loaded configuration, nested configurations and overrides reject them, so that
+// every rule has the same severity in all rule sets, as the formatters and the fail policy expect.
+func EnabledRulesFor(ruleConfigurations config.RuleConfigurations) []types.Rule {
+	var enabledRules []types.Rule
+	for _, rule := range rules {
+		ruleConfiguration := ruleConfigurations.ByID(rule.ID())
+		if !ruleConfiguration.IsEnabled() {
+			continue
 		}
+		ruleConfiguration.Severity = config.GetConfigByRuleID(rule.ID()).Severity
+		enabledRules = append(enabledRules, configuredBy(rule, ruleConfiguration))
 	}
 	return enabledRules
 }
@@ -46,7 +74,55 @@ func FindByID(id string) (types.Rule, error) {
 	if !ok {
 		return nil, fmt.Errorf("no rule found for ID %s", id)
 	}
-	return rule, nil
+	return configured(rule), nil
+}
+
+// severityOverride replaces the severity in the metadata of a rule.
+type severityOverride struct {
+	types.Rule
+	severity types.Severity
+}
+
+func (r *severityOverride) META() types.RuleMeta {
+	meta := r.Rule.META()
+	meta.Severity = r.severity
+	return meta
+}
+
+// OptionsByID returns the options declared by a rule, or nil if it has none.
+func OptionsByID(id string) []types.RuleOption {
+	return optionsOf(ruleMap[id])
+}
+
+func optionsOf(rule types.Rule) []types.RuleOption {
+	if configurable, ok := rule.(types.ConfigurableRule); ok {
+		return configurable.Options()
+	}
+	return nil
+}
+
+// configured creates a rule with the options of the configuration and applies its severity override.
+func configured(rule types.Rule) types.Rule {
+	return configuredBy(rule, config.GetConfigByRuleID(rule.ID()))
+}
+
+// configuredBy creates a rule with the options of ruleConfiguration and applies its severity override. The
+// configuration has been validated already, so invalid values are ignored here.
+func configuredBy(rule types.Rule, ruleConfiguration config.RuleConfiguration) types.Rule {
+	if configurable, ok := rule.(types.ConfigurableRule); ok {
+		if options, err := types.ParseRuleOptions(configurable.Options(), ruleConfiguration.Spec); err == nil {
+			rule = configurable.WithOptions(options)
+		}
+	}
+
+	if ruleConfiguration.Severity == "" {
+		return rule
+	}
+	severity, err := constants.ParseSeverity(ruleConfiguration.Severity)
+	if err != nil {
+		return rule
+	}
+	return &severityOverride{Rule: rule, severity: severity}
 }
 
 func mapRules(rulesList []types.Rule) map[string]types.Rule {
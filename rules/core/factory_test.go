@@ -0,0 +1,192 @@
+package core_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Marcel2603/tfcoach/cmd/config"
+	"github.com/Marcel2603/tfcoach/internal/constants"
+	"github.com/Marcel2603/tfcoach/internal/testutil"
+	"github.com/Marcel2603/tfcoach/rules/core"
+)
+
+func TestSeverityOverride(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	configPath := filepath.Join(t.TempDir(), ".tfcoach.yml")
+	content := `rules:
+  core.naming_convention:
+    severity: info
+  core.file_naming:
+    enabled: false
+    severity: high
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("Setup error: %v", err)
+	}
+	if err := config.LoadConfig(&config.DefaultNavigator{CustomConfigPath: configPath}); err != nil {
+		t.Fatalf("Setup error: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := config.LoadDefaultConfig(); err != nil {
+			t.Fatalf("Cleanup error: %v", err)
+		}
+	})
+
+	rule, err := core.FindByID("core.naming_convention")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := rule.META().Severity; got != constants.SeverityInfo {
+		t.Errorf("FindByID() severity = %s, want %s", got, constants.SeverityInfo)
+	}
+	if got := rule.META().Title; got != core.NamingConventionRule().META().Title {
+		t.Errorf("FindByID() title = %s, want the title of the rule", got)
+	}
+
+	for _, r := range core.EnabledRules() {
+		if r.ID() == "core.naming_convention" && r.META().Severity != constants.SeverityInfo {
+			t.Errorf("EnabledRules() severity = %s, want %s", r.META().Severity, constants.SeverityInfo)
+		}
+		if r.ID() == "core.file_naming" {
+			t.Errorf("EnabledRules() contains disabled rule %s", r.ID())
+		}
+	}
+
+	for _, r := range core.All() {
+		if r.ID() == "core.file_naming" && r.META().Severity != constants.SeverityHigh {
+			t.Errorf("All() severity = %s, want %s", r.META().Severity, constants.SeverityHigh)
+		}
+		if r.ID() == "core.avoid_type_in_name" && r.META().Severity != constants.SeverityHigh {
+			t.Errorf("All() changed the severity of a rule without override to %s", r.META().Severity)
+		}
+	}
+}
+
+func TestConfiguredOptions(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	configPath := filepath.Join(t.TempDir(), ".tfcoach.yml")
+	content := `rules:
+  core.naming_convention:
+    spec:
+      patterns:
+        resource: kebab-case
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("Setup error: %v", err)
+	}
+	if err := config.LoadConfig(&config.DefaultNavigator{CustomConfigPath: configPath}); err != nil {
+		t.Fatalf("Setup error: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := config.LoadDefaultConfig(); err != nil {
+			t.Fatalf("Cleanup error: %v", err)
+		}
+	})
+
+	rule, err := core.FindByID("core.naming_convention")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	f := testutil.ParseToHcl(t, "main.tf", `resource "test_resource" "foo-bar" {}`)
+	if issues := rule.Apply("main.tf", f); len(issues) != 0 {
+		t.Errorf("expected the configured pattern to be used; got %#v", issues)
+	}
+}
+
+func TestConfiguredOptions_InvalidSpec(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	configPath := filepath.Join(t.TempDir(), ".tfcoach.yml")
+	content := `rules:
+  core.naming_convention:
+    spec:
+      max_length: long
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("Setup error: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := config.LoadDefaultConfig(); err != nil {
+			t.Fatalf("Cleanup error: %v", err)
+		}
+	})
+
+	err := config.LoadConfig(&config.DefaultNavigator{CustomConfigPath: configPath})
+	if err == nil || !strings.Contains(err.Error(), "invalid spec for rule core.naming_convention") {
+		t.Errorf("LoadConfig() error = %v, want an invalid spec error", err)
+	}
+}
+
+func TestEnabledRulesFor(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	configPath := filepath.Join(t.TempDir(), ".tfcoach.yml")
+	content := `rules:
+  core.naming_convention:
+    severity: info
+    enabled: true
+`
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("Setup error: %v", err)
+	}
+	if err := config.LoadConfig(&config.DefaultNavigator{CustomConfigPath: configPath}); err != nil {
+		t.Fatalf("Setup error: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := config.LoadDefaultConfig(); err != nil {
+			t.Fatalf("Cleanup error: %v", err)
+		}
+	})
+
+	ruleConfigurations := config.RuleConfigurations{
+		"core.file_naming": {Enabled: config.NullableBool{HasValue: true}},
+		"core.naming_convention": {
+			Enabled: config.NullableBool{HasValue: true, IsTrue: true},
+			Spec:    map[string]any{"patterns": map[string]any{"resource": "kebab-case"}},
+		},
+	}
+	enabledRules := core.EnabledRulesFor(ruleConfigurations)
+	if len(enabledRules) != len(core.All())-1 {
+		t.Errorf("EnabledRulesFor() returned %d rules, want all but one", len(enabledRules))
+	}
+	for _, rule := range enabledRules {
+		switch rule.ID() {
+		case "core.file_naming":
+			t.Errorf("EnabledRulesFor() contains disabled rule %s", rule.ID())
+		case "core.naming_convention":
+			if rule.META().Severity != constants.SeverityInfo {
+				t.Errorf("EnabledRulesFor() severity = %s, want the severity of the loaded config", rule.META().Severity)
+			}
+			f := testutil.ParseToHcl(t, "main.tf", `resource "test_resource" "foo-bar" {}`)
+			if issues := rule.Apply("main.tf", f); len(issues) != 0 {
+				t.Errorf("expected the configured pattern to be used; got %#v", issues)
+			}
+		}
+	}
+}
+
+func TestPresetsConfigureKnownRules(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() {
+		if err := config.LoadDefaultConfig(); err != nil {
+			t.Fatalf("Cleanup error: %v", err)
+		}
+	})
+
+	for _, preset := range config.PresetNames() {
+		t.Run(preset, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), ".tfcoach.yml")
+			if err := os.WriteFile(configPath, []byte("extends: ["+preset+"]\n"), 0o644); err != nil {
+				t.Fatalf("Setup error: %v", err)
+			}
+			if err := config.LoadConfig(&config.DefaultNavigator{CustomConfigPath: configPath}); err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			for ruleID := range config.GetRuleConfigurations() {
+				if _, err := core.FindByID(ruleID); err != nil {
+					t.Errorf("preset %s configures unknown rule %s", preset, ruleID)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
 	"maps"
 	"path"
@@ -14,30 +16,59 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
+const (
+	fileNamingOptionBlocks          = "blocks"
+	fileNamingOptionTerraformBlocks = "terraform_blocks"
+	fileNamingOptionTerraformFiles  = "terraform_files"
+	fileNamingOptionResourceTypes   = "resource_types"
+)
+
+// FileNaming maps blocks to the files they belong in. All file names are glob patterns matched against the base name
+// of the file.
 type FileNaming struct {
-	id string
+	id                     string
+	generalTypeToFiles     map[string][]string
+	terraformBlkTypeToFile map[string][]string
+	terraformFiles         []string
+	// resource type patterns sorted from most to least specific
+	resourceTypePatterns []string
+	resourceTypeToFiles  map[string][]string
 }
 
 var (
-	generalTypeToFile = map[string]string{
-		"output":   "outputs.tf",
-		"variable": "variables.tf",
-		"locals":   "locals.tf",
-		"provider": "providers.tf",
-		"data":     "data.tf",
-	}
-	terraformBlkTypeToFile = map[string]string{
-		"backend":            "backend.tf",
-		"cloud":              "backend.tf",
-		"required_providers": "terraform.tf",
-		"provider_meta":      "terraform.tf",
-	}
-	defaultTerraformFilename = "terraform.tf"
+	generalTypeToFile = map[string][]string{
+		"output":   {"outputs.tf"},
+		"variable": {"variables.tf"},
+		"locals":   {"locals.tf"},
+		"provider": {"providers.tf"},
+		"data":     {"data.tf"},
+	}
+	// blocks inside of "terraform" that are not listed here belong in the terraform files
+	terraformBlkTypeToFile = map[string][]string{
+		"backend": {"backend.tf"},
+		"cloud":   {"backend.tf"},
+	}
+	defaultTerraformFilenames = []string{"terraform.tf"}
 )
 
 func FileNamingRule() *FileNaming {
+	return newFileNaming(defaultOptionsOf(&FileNaming{}))
+}
+
+func newFileNaming(options types.RuleOptions) *FileNaming {
+	resourceTypeToFiles := options.StringListMap(fileNamingOptionResourceTypes)
+	resourceTypePatterns := slices.SortedFunc(maps.Keys(resourceTypeToFiles), func(a, b string) int {
+		return cmp.Or(cmp.Compare(len(b), len(a)), strings.Compare(a, b))
+	})
+
 	return &FileNaming{
 		id: rulePrefix + ".file_naming",
+		// configured block types are merged into the defaults, so that e.g. only "output" can be changed
+		generalTypeToFiles:     mergeFileMappings(generalTypeToFile, options.StringListMap(fileNamingOptionBlocks)),
+		terraformBlkTypeToFile: mergeFileMappings(terraformBlkTypeToFile, options.StringListMap(fileNamingOptionTerraformBlocks)),
+		terraformFiles:         options.StringList(fileNamingOptionTerraformFiles),
+		resourceTypePatterns:   resourceTypePatterns,
+		resourceTypeToFiles:    resourceTypeToFiles,
 	}
 }
 
@@ -54,6 +85,57 @@ func (r *FileNaming) META() types.RuleMeta {
 	}
 }
 
+func (*FileNaming) Options() []types.RuleOption {
+	return []types.RuleOption{
+		{
+			Name:        fileNamingOptionBlocks,
+			Type:        types.RuleOptionStringListMap,
+			Default:     generalTypeToFile,
+			Description: "Allowed files per block type, merged into the defaults; an empty list allows any file",
+			Validate:    validateFilePatternMap,
+		},
+		{
+			Name:        fileNamingOptionTerraformBlocks,
+			Type:        types.RuleOptionStringListMap,
+			Default:     terraformBlkTypeToFile,
+			Description: "Allowed files per block type inside of \"terraform\", merged into the defaults",
+			Validate:    validateFilePatternMap,
+		},
+		{
+			Name:        fileNamingOptionTerraformFiles,
+			Type:        types.RuleOptionStringList,
+			Default:     defaultTerraformFilenames,
+			Description: "Allowed files for the attributes and all other blocks inside of \"terraform\"",
+			Validate: func(value any) error {
+				patterns, _ := value.([]string)
+				if len(patterns) == 0 {
+					return errors.New("must not be empty")
+				}
+				return validateFilePatterns(patterns)
+			},
+		},
+		{
+			Name:        fileNamingOptionResourceTypes,
+			Type:        types.RuleOptionStringListMap,
+			Default:     map[string][]string{},
+			Description: "Allowed files per resource or data source type pattern, e.g. \"aws_iam_*\"",
+			Validate: func(value any) error {
+				patternMap, _ := value.(map[string][]string)
+				for _, resourceType := range slices.Sorted(maps.Keys(patternMap)) {
+					if _, err := path.Match(resourceType, ""); err != nil {
+						return fmt.Errorf("invalid resource type pattern %q: %w", resourceType, err)
+					}
+				}
+				return validateFilePatternMap(value)
+			},
+		},
+	}
+}
+
+func (*FileNaming) WithOptions(options types.RuleOptions) types.Rule {
+	return newFileNaming(options)
+}
+
 func (r *FileNaming) Apply(file string, f *hcl.File) []types.Issue {
 	body, ok := f.Body.(*hclsyntax.Body)
 	if !ok {
@@ -71,9 +153,15 @@ func (r *FileNaming) Apply(file string, f *hcl.File) []types.Issue {
 			}
 			continue
 		}
-		if compliantFile, ok := generalTypeToFile[blkType]; ok {
-			if fileName != compliantFile {
-				out = append(out, r.createIssue(file, compliantFile, blkType, "Block", blk.Range()))
+		if issue, routed := r.analyzeResourceType(file, fileName, blk); routed {
+			if issue != nil {
+				out = append(out, *issue)
+			}
+			continue
+		}
+		if compliantFiles, ok := r.generalTypeToFiles[blkType]; ok {
+			if !matchesAnyFile(fileName, compliantFiles) {
+				out = append(out, r.createIssue(file, compliantFiles, blkType, "Block", blk.Range()))
 			}
 		}
 	}
@@ -84,32 +172,57 @@ func (*FileNaming) Finish() []types.Issue {
 	return []types.Issue{}
 }
 
-func (r *FileNaming) createIssue(file string, compliantFile string, hclType string, hclDataType string, hclRange hcl.Range) types.Issue {
+func (r *FileNaming) createIssue(file string, compliantFiles []string, hclType string, hclDataType string, hclRange hcl.Range) types.Issue {
 	return types.Issue{
 		File:    file,
 		Range:   hclRange,
-		Message: fmt.Sprintf(`%s "%s" should be inside of %s.`, hclDataType, hclType, compliantFile),
+		Message: fmt.Sprintf(`%s "%s" should be inside of %s.`, hclDataType, hclType, strings.Join(compliantFiles, " or ")),
 		RuleID:  r.id,
 	}
 }
 
+// analyzeResourceType checks resources and data sources whose type matches one of the configured resource type
+// patterns. The result is false if the block is not routed by its type.
+func (r *FileNaming) analyzeResourceType(file string, fileName string, blk *hclsyntax.Block) (*types.Issue, bool) {
+	if (blk.Type != "resource" && blk.Type != "data") || len(blk.Labels) == 0 {
+		return nil, false
+	}
+	resourceType := blk.Labels[0]
+	for _, pattern := range r.resourceTypePatterns {
+		if matched, _ := path.Match(pattern, resourceType); !matched {
+			continue
+		}
+		compliantFiles := r.resourceTypeToFiles[pattern]
+		if matchesAnyFile(fileName, compliantFiles) {
+			return nil, true
+		}
+		hclDataType := "Resource"
+		if blk.Type == "data" {
+			hclDataType = "Data source"
+		}
+		issue := r.createIssue(file, compliantFiles, resourceType, hclDataType, blk.Range())
+		return &issue, true
+	}
+	return nil, false
+}
+
 func (r *FileNaming) analyzeTerraformType(file string, fileName string, terraformBlk *hclsyntax.Block) []types.Issue {
 	var issues []types.Issue
 	issues = append(issues, r.analyzeAllowedFilenamesForTerraformBlock(file, fileName, terraformBlk)...)
 	for _, blk := range terraformBlk.Body.Blocks {
-		typeFile, ok := terraformBlkTypeToFile[blk.Type]
-		compliantFilename := defaultTerraformFilename
+		typeFiles, ok := r.terraformBlkTypeToFile[blk.Type]
+		compliantFilenames := r.terraformFiles
 		if ok {
-			compliantFilename = typeFile
+			compliantFilenames = typeFiles
 		}
-		if fileName != compliantFilename {
-			issues = append(issues, r.createIssue(file, compliantFilename, blk.Type, "Block", blk.Range()))
+		if !matchesAnyFile(fileName, compliantFilenames) {
+			issues = append(issues, r.createIssue(file, compliantFilenames, blk.Type, "Block", blk.Range()))
 		}
 	}
 
 	for _, attr := range terraformBlk.Body.Attributes {
-		if fileName != defaultTerraformFilename {
-			issues = append(issues, r.createIssue(file, defaultTerraformFilename, attr.Name, "Attribute", attr.Range()))
+		if !matchesAnyFile(fileName, r.terraformFiles) {
+			issues = append(issues, r.createIssue(file, r.terraformFiles, attr.Name, "Attribute", attr.Range()))
 		}
 	}
 
@@ -117,11 +230,54 @@ func (r *FileNaming) analyzeTerraformType(file string, fileName string, terrafor
 }
 
 func (r *FileNaming) analyzeAllowedFilenamesForTerraformBlock(file string, fileName string, terraformBlk *hclsyntax.Block) []types.Issue {
-	files := slices.Collect(maps.Values(terraformBlkTypeToFile))
-	files = utils.SortAndDeduplicate(append(files, defaultTerraformFilename))
+	files := slices.Concat(slices.Collect(maps.Values(r.terraformBlkTypeToFile))...)
+	files = utils.SortAndDeduplicate(append(files, r.terraformFiles...))
 	var issues []types.Issue
-	if !slices.Contains(files, fileName) {
-		issues = append(issues, r.createIssue(file, fmt.Sprintf("%+v", files), terraformBlk.Type, "Block", terraformBlk.Range()))
+	if !matchesAnyFile(fileName, files) {
+		issues = append(issues, types.Issue{
+			File:    file,
+			Range:   terraformBlk.Range(),
+			Message: fmt.Sprintf(`Block "%s" should be inside of %+v.`, terraformBlk.Type, files),
+			RuleID:  r.id,
+		})
 	}
 	return issues
 }
+
+// matchesAnyFile reports whether fileName matches one of the patterns. An empty list of patterns allows any file.
+func matchesAnyFile(fileName string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, fileName); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func mergeFileMappings(defaults map[string][]string, configured map[string][]string) map[string][]string {
+	merged := maps.Clone(defaults)
+	maps.Copy(merged, configured)
+	return merged
+}
+
+func validateFilePatternMap(value any) error {
+	patternMap, _ := value.(map[string][]string)
+	for _, key := range slices.Sorted(maps.Keys(patternMap)) {
+		if err := validateFilePatterns(patternMap[key]); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func validateFilePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid file pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
@@ -240,3 +240,144 @@ func TestFileNaming_ShouldFailOnMisconfiguredTerraformBlocks(t *testing.T) {
 	}
 
 }
+
+func TestFileNaming_Options(t *testing.T) {
+	cases := []struct {
+		name     string
+		spec     map[string]any
+		filename string
+		resource string
+		issues   []string
+	}{
+		{
+			name:     "alternative file name",
+			spec:     map[string]any{"blocks": map[string]any{"output": []any{"outputs.tf", "output.tf"}}},
+			filename: "output.tf",
+			resource: `output "test" {}`,
+		},
+		{
+			name:     "alternative file name in message",
+			spec:     map[string]any{"blocks": map[string]any{"output": []any{"outputs.tf", "output.tf"}}},
+			filename: "main.tf",
+			resource: `output "test" {}`,
+			issues:   []string{`Block "output" should be inside of outputs.tf or output.tf.`},
+		},
+		{
+			name:     "configured block types keep the other defaults",
+			spec:     map[string]any{"blocks": map[string]any{"output": []any{"output.tf"}}},
+			filename: "main.tf",
+			resource: `variable "test" {}`,
+			issues:   []string{`Block "variable" should be inside of variables.tf.`},
+		},
+		{
+			name:     "glob",
+			spec:     map[string]any{"blocks": map[string]any{"variable": []any{"variables*.tf"}}},
+			filename: "variables_network.tf",
+			resource: `variable "test" {}`,
+		},
+		{
+			name:     "empty list allows any file",
+			spec:     map[string]any{"blocks": map[string]any{"data": []any{}}},
+			filename: "main.tf",
+			resource: `data "test" "test" {}`,
+		},
+		{
+			name:     "new block type",
+			spec:     map[string]any{"blocks": map[string]any{"module": []any{"modules.tf"}}},
+			filename: "main.tf",
+			resource: `module "test" {}`,
+			issues:   []string{`Block "module" should be inside of modules.tf.`},
+		},
+		{
+			name:     "versions.tf",
+			spec:     map[string]any{"terraform_files": []any{"versions.tf"}},
+			filename: "versions.tf",
+			resource: `terraform {
+				required_version = "1.0.0"
+				required_providers {}
+			}`,
+		},
+		{
+			name:     "versions.tf in message",
+			spec:     map[string]any{"terraform_files": []any{"versions.tf"}},
+			filename: "main.tf",
+			resource: `terraform {
+				required_version = "1.0.0"
+			}`,
+			issues: []string{
+				`Block "terraform" should be inside of [backend.tf versions.tf].`,
+				`Attribute "required_version" should be inside of versions.tf.`,
+			},
+		},
+		{
+			name:     "backend in versions.tf",
+			spec:     map[string]any{"terraform_blocks": map[string]any{"backend": []any{"versions.tf"}}, "terraform_files": []any{"versions.tf"}},
+			filename: "versions.tf",
+			resource: `terraform {
+				backend "s3" {}
+			}`,
+		},
+		{
+			name:     "resource type",
+			spec:     map[string]any{"resource_types": map[string]any{"aws_iam_*": []any{"iam.tf"}}},
+			filename: "main.tf",
+			resource: `resource "aws_iam_role" "test" {}
+				resource "aws_s3_bucket" "test" {}
+				data "aws_iam_policy_document" "test" {}`,
+			issues: []string{
+				`Resource "aws_iam_role" should be inside of iam.tf.`,
+				`Data source "aws_iam_policy_document" should be inside of iam.tf.`,
+			},
+		},
+		{
+			name:     "resource type overrides block mapping",
+			spec:     map[string]any{"resource_types": map[string]any{"aws_iam_*": []any{"iam.tf"}}},
+			filename: "iam.tf",
+			resource: `data "aws_iam_policy_document" "test" {}`,
+		},
+		{
+			name: "most specific resource type wins",
+			spec: map[string]any{"resource_types": map[string]any{
+				"aws_*":          []any{"aws.tf"},
+				"aws_iam_role_*": []any{"roles.tf"},
+			}},
+			filename: "aws.tf",
+			resource: `resource "aws_iam_role_policy" "test" {}`,
+			issues:   []string{`Resource "aws_iam_role_policy" should be inside of roles.tf.`},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := testutil.RuleWithSpec(t, core.FileNamingRule(), tt.spec)
+			issues := rule.Apply(tt.filename, testutil.ParseToHcl(t, tt.filename, tt.resource))
+			if len(issues) != len(tt.issues) {
+				t.Fatalf("Issues found; expected %d; got %d: %#v", len(tt.issues), len(issues), issues)
+			}
+			for _, issue := range issues {
+				if !slices.Contains(tt.issues, issue.Message) {
+					t.Fatalf("Found unexpected Issue %s", issue.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestFileNaming_InvalidOptions(t *testing.T) {
+	cases := []struct {
+		name string
+		spec map[string]any
+	}{
+		{name: "invalid file pattern", spec: map[string]any{"blocks": map[string]any{"output": []any{"[outputs.tf"}}}},
+		{name: "invalid resource type pattern", spec: map[string]any{"resource_types": map[string]any{"aws_[": []any{"aws.tf"}}}},
+		{name: "no terraform files", spec: map[string]any{"terraform_files": []any{}}},
+		{name: "single file instead of list", spec: map[string]any{"blocks": map[string]any{"output": "output.tf"}}},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := types.ParseRuleOptions(core.FileNamingRule().Options(), tt.spec); err == nil {
+				t.Errorf("expected an error for %v", tt.spec)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package core
 
-import "github.com/hashicorp/hcl/v2/hclsyntax"
+import (
+	"github.com/Marcel2603/tfcoach/internal/types"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
 
 func nameOf(block *hclsyntax.Block) string {
 	if len(block.Labels) == 0 {
@@ -10,3 +13,9 @@ func nameOf(block *hclsyntax.Block) string {
 	// <block_type> "<label1>" "<label2>"
 	return block.Labels[len(block.Labels)-1]
 }
+
+// defaultOptionsOf returns the options of a rule that is not configured.
+func defaultOptionsOf(rule types.ConfigurableRule) types.RuleOptions {
+	options, _ := types.ParseRuleOptions(rule.Options(), nil)
+	return options
+}
@@ -1,9 +1,13 @@
 package core
 
 import (
+	"errors"
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/Marcel2603/tfcoach/internal/constants"
 	"github.com/Marcel2603/tfcoach/internal/types"
@@ -11,15 +15,83 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
-var nameFormatRegex = regexp.MustCompile(`^[a-z0-9_]+$`)
+const (
+	namingOptionPreset    = "preset"
+	namingOptionPatterns  = "patterns"
+	namingOptionMaxLength = "max_length"
+
+	defaultNamingPreset = "snake_case"
+	localsBlockType     = "locals"
+)
+
+var (
+	namingPresets = map[string]nameConvention{
+		"snake_case": {
+			description: "snake_case (lowercase alphanumeric characters and underscores)",
+			requirement: "only contain lowercase alphanumeric characters and underscores",
+			regex:       regexp.MustCompile(`^[a-z0-9_]+$`),
+		},
+		"kebab-case": {
+			description: "kebab-case (lowercase alphanumeric characters and hyphens)",
+			requirement: "only contain lowercase alphanumeric characters and hyphens",
+			regex:       regexp.MustCompile(`^[a-z0-9-]+$`),
+		},
+		"camelCase": {
+			description: "camelCase (alphanumeric characters, starting with a lowercase letter)",
+			requirement: "only contain alphanumeric characters and start with a lowercase letter",
+			regex:       regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`),
+		},
+	}
+
+	// block types that can get their own pattern, with the wording used in messages
+	namingBlockTypes = map[string]string{
+		"resource":      "resource names",
+		"data":          "data source names",
+		"module":        "module names",
+		"variable":      "variable names",
+		"output":        "output names",
+		localsBlockType: "local names",
+	}
+)
+
+type nameConvention struct {
+	description string
+	// requirement completes "names should ..." in the description of the rule
+	requirement string
+	regex       *regexp.Regexp
+}
 
 type NamingConvention struct {
 	id string
+	// conventions by block type, blocks of other types use fallback
+	conventions map[string]nameConvention
+	fallback    nameConvention
+	maxLength   int
 }
 
 func NamingConventionRule() *NamingConvention {
+	return newNamingConvention(defaultOptionsOf(&NamingConvention{}))
+}
+
+func newNamingConvention(options types.RuleOptions) *NamingConvention {
+	fallback := namingPresets[options.String(namingOptionPreset)]
+	if fallback.regex == nil {
+		fallback = namingPresets[defaultNamingPreset]
+	}
+
+	conventions := make(map[string]nameConvention)
+	for blockType, pattern := range options.StringMap(namingOptionPatterns) {
+		// options have been validated already
+		if convention, err := parseNameConvention(pattern); err == nil {
+			conventions[blockType] = convention
+		}
+	}
+
 	return &NamingConvention{
-		id: rulePrefix + ".naming_convention",
+		id:          rulePrefix + ".naming_convention",
+		conventions: conventions,
+		fallback:    fallback,
+		maxLength:   options.Int(namingOptionMaxLength),
 	}
 }
 
@@ -30,12 +102,67 @@ func (n *NamingConvention) ID() string {
 func (n *NamingConvention) META() types.RuleMeta {
 	return types.RuleMeta{
 		Title:       "Naming Convention",
-		Description: "Terraform names should only contain lowercase alphanumeric characters and underscores.",
+		Description: n.description(),
 		Severity:    constants.SeverityHigh,
 		DocsURI:     strings.ReplaceAll(n.id, ".", "/"),
 	}
 }
 
+// description describes the configured conventions, e.g. "Terraform names should only contain lowercase alphanumeric
+// characters and underscores." for the default options.
+func (n *NamingConvention) description() string {
+	sentences := []string{fmt.Sprintf("Terraform names should %s.", n.fallback.requirement)}
+	for _, blockType := range slices.Sorted(maps.Keys(n.conventions)) {
+		subject := namingBlockTypes[blockType]
+		sentences = append(sentences, fmt.Sprintf(
+			"%s%s should %s.",
+			strings.ToUpper(subject[:1]),
+			subject[1:],
+			n.conventions[blockType].requirement,
+		))
+	}
+	if n.maxLength > 0 {
+		sentences = append(sentences, fmt.Sprintf("Names should be at most %d characters long.", n.maxLength))
+	}
+	return strings.Join(sentences, " ")
+}
+
+func (*NamingConvention) Options() []types.RuleOption {
+	return []types.RuleOption{
+		{
+			Name:        namingOptionPreset,
+			Type:        types.RuleOptionString,
+			Default:     defaultNamingPreset,
+			Description: "Convention for all names without a pattern",
+			Allowed:     slices.Sorted(maps.Keys(namingPresets)),
+		},
+		{
+			Name:    namingOptionPatterns,
+			Type:    types.RuleOptionStringMap,
+			Default: map[string]string{},
+			Description: "Regex or preset per block type (" +
+				strings.Join(slices.Sorted(maps.Keys(namingBlockTypes)), ", ") + ")",
+			Validate: validateNamingPatterns,
+		},
+		{
+			Name:        namingOptionMaxLength,
+			Type:        types.RuleOptionNumber,
+			Default:     0,
+			Description: "Maximum length of names, 0 for no limit",
+			Validate: func(value any) error {
+				if maxLength, _ := value.(int); maxLength < 0 {
+					return errors.New("must not be negative")
+				}
+				return nil
+			},
+		},
+	}
+}
+
+func (*NamingConvention) WithOptions(options types.RuleOptions) types.Rule {
+	return newNamingConvention(options)
+}
+
 func (n *NamingConvention) Apply(file string, f *hcl.File) []types.Issue {
 	body, ok := f.Body.(*hclsyntax.Body)
 	if !ok {
@@ -43,14 +170,21 @@ func (n *NamingConvention) Apply(file string, f *hcl.File) []types.Issue {
 	}
 	var out []types.Issue
 	for _, blk := range body.Blocks {
+		if blk.Type == localsBlockType {
+			for _, attribute := range sortedAttributes(blk.Body) {
+				if message, broken := n.check("Local", localsBlockType, attribute.Name); broken {
+					out = append(out, types.Issue{File: file, Range: attribute.SrcRange, Message: message, RuleID: n.id})
+				}
+			}
+			continue
+		}
+
 		name := nameOf(blk)
-		if name != "" && !nameFormatRegex.MatchString(name) {
-			out = append(out, types.Issue{
-				File:    file,
-				Range:   blk.Range(),
-				Message: fmt.Sprintf("Block \"%s\" violates naming convention, it should only contain lowercase alphanumeric characters and underscores.", name),
-				RuleID:  n.id,
-			})
+		if name == "" {
+			continue
+		}
+		if message, broken := n.check("Block", blk.Type, name); broken {
+			out = append(out, types.Issue{File: file, Range: blk.Range(), Message: message, RuleID: n.id})
 		}
 	}
 	return out
@@ -59,3 +193,63 @@ func (n *NamingConvention) Apply(file string, f *hcl.File) []types.Issue {
 func (*NamingConvention) Finish() []types.Issue {
 	return []types.Issue{}
 }
+
+// check returns the message for name if it breaks the convention of its block type.
+func (n *NamingConvention) check(kind string, blockType string, name string) (string, bool) {
+	subject, known := namingBlockTypes[blockType]
+	if !known {
+		subject = "names"
+	}
+	convention, ok := n.conventions[blockType]
+	if !ok {
+		convention = n.fallback
+	}
+
+	if !convention.regex.MatchString(name) {
+		return fmt.Sprintf("%s \"%s\" violates naming convention, %s should match %s.", kind, name, subject, convention.description), true
+	}
+	if length := utf8.RuneCountInString(name); n.maxLength > 0 && length > n.maxLength {
+		return fmt.Sprintf(
+			"%s \"%s\" violates naming convention, %s should be at most %d characters long (is %d).",
+			kind, name, subject, n.maxLength, length,
+		), true
+	}
+	return "", false
+}
+
+func sortedAttributes(body *hclsyntax.Body) []*hclsyntax.Attribute {
+	attributes := slices.Collect(maps.Values(body.Attributes))
+	slices.SortFunc(attributes, func(a, b *hclsyntax.Attribute) int {
+		return a.SrcRange.Start.Byte - b.SrcRange.Start.Byte
+	})
+	return attributes
+}
+
+// parseNameConvention accepts the name of a preset or a regex.
+func parseNameConvention(pattern string) (nameConvention, error) {
+	if preset, ok := namingPresets[pattern]; ok {
+		return preset, nil
+	}
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return nameConvention{}, err
+	}
+	return nameConvention{description: pattern, requirement: "match " + pattern, regex: regex}, nil
+}
+
+func validateNamingPatterns(value any) error {
+	patterns, _ := value.(map[string]string)
+	for _, blockType := range slices.Sorted(maps.Keys(patterns)) {
+		if _, ok := namingBlockTypes[blockType]; !ok {
+			return fmt.Errorf(
+				"unknown block type %q (supported: %s)",
+				blockType,
+				strings.Join(slices.Sorted(maps.Keys(namingBlockTypes)), ", "),
+			)
+		}
+		if _, err := parseNameConvention(patterns[blockType]); err != nil {
+			return fmt.Errorf("pattern for %s: %w", blockType, err)
+		}
+	}
+	return nil
+}
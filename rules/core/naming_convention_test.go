@@ -25,6 +25,22 @@ func TestNameFormat_ExpectedMETA(t *testing.T) {
 	}
 }
 
+func TestNameFormat_DescriptionOfOptions(t *testing.T) {
+	rule := testutil.RuleWithSpec(t, core.NamingConventionRule(), map[string]any{
+		"preset":     "kebab-case",
+		"patterns":   map[string]any{"output": "^[a-z]+$", "module": "camelCase"},
+		"max_length": 40,
+	})
+
+	want := "Terraform names should only contain lowercase alphanumeric characters and hyphens. " +
+		"Module names should only contain alphanumeric characters and start with a lowercase letter. " +
+		"Output names should match ^[a-z]+$. " +
+		"Names should be at most 40 characters long."
+	if got := rule.META().Description; got != want {
+		t.Fatalf("description mismatch; got %q, wanted %q", got, want)
+	}
+}
+
 func TestNameFormat_AllGood(t *testing.T) {
 	f := testutil.ParseToHcl(t, "good.tf", `
 		resource "test_resource" "foo_bar_9" {}
@@ -123,3 +139,122 @@ func TestNameFormat_FinishShouldDoNothing(t *testing.T) {
 		t.Fatalf("Issues found; expected none; got %d: %#v", len(issues), issues)
 	}
 }
+
+func TestNameFormat_FailedLocals(t *testing.T) {
+	f := testutil.ParseToHcl(t, "bad.tf", `
+		locals {
+			good_name = 1
+			BadName   = 2
+		}
+	`)
+
+	rule := core.NamingConventionRule()
+	issues := rule.Apply("bad.tf", f)
+
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue; got %d: %#v", len(issues), issues)
+	}
+	want := `Local "BadName" violates naming convention, local names should match snake_case (lowercase alphanumeric characters and underscores).`
+	if issues[0].Message != want {
+		t.Errorf("got message %q, want %q", issues[0].Message, want)
+	}
+	if issues[0].Range.Start.Line != 4 {
+		t.Errorf("expected the issue at the attribute in line 4, got line %d", issues[0].Range.Start.Line)
+	}
+}
+
+func TestNameFormat_Options(t *testing.T) {
+	src := `
+		resource "test_resource" "foo-bar" {}
+		data "test_data" "fooBar" {}
+		module "foo_bar" {}
+		variable "a_very_long_variable_name" {}
+		output "fooBar" {}
+		locals { foo_bar = 1 }
+	`
+	tests := []struct {
+		name string
+		spec map[string]any
+		want []string
+	}{
+		{
+			name: "default",
+			spec: nil,
+			want: []string{
+				`Block "foo-bar" violates naming convention, resource names should match snake_case (lowercase alphanumeric characters and underscores).`,
+				`Block "fooBar" violates naming convention, data source names should match snake_case (lowercase alphanumeric characters and underscores).`,
+				`Block "fooBar" violates naming convention, output names should match snake_case (lowercase alphanumeric characters and underscores).`,
+			},
+		},
+		{
+			name: "preset",
+			spec: map[string]any{"preset": "camelCase"},
+			want: []string{
+				`Block "foo-bar" violates naming convention, resource names should match camelCase (alphanumeric characters, starting with a lowercase letter).`,
+				`Block "foo_bar" violates naming convention, module names should match camelCase (alphanumeric characters, starting with a lowercase letter).`,
+				`Block "a_very_long_variable_name" violates naming convention, variable names should match camelCase (alphanumeric characters, starting with a lowercase letter).`,
+				`Local "foo_bar" violates naming convention, local names should match camelCase (alphanumeric characters, starting with a lowercase letter).`,
+			},
+		},
+		{
+			name: "patterns per block type",
+			spec: map[string]any{"patterns": map[string]any{
+				"resource": "kebab-case",
+				"data":     "camelCase",
+				"output":   "^[a-zA-Z]+$",
+			}},
+			want: nil,
+		},
+		{
+			name: "max length",
+			spec: map[string]any{"patterns": map[string]any{"resource": "kebab-case"}, "max_length": 20},
+			want: []string{
+				`Block "fooBar" violates naming convention, data source names should match snake_case (lowercase alphanumeric characters and underscores).`,
+				`Block "a_very_long_variable_name" violates naming convention, variable names should be at most 20 characters long (is 25).`,
+				`Block "fooBar" violates naming convention, output names should match snake_case (lowercase alphanumeric characters and underscores).`,
+			},
+		},
+		{
+			name: "regex in message",
+			spec: map[string]any{"patterns": map[string]any{"resource": "^[a-z]+_[a-z]+$"}},
+			want: []string{
+				`Block "foo-bar" violates naming convention, resource names should match ^[a-z]+_[a-z]+$.`,
+				`Block "fooBar" violates naming convention, data source names should match snake_case (lowercase alphanumeric characters and underscores).`,
+				`Block "fooBar" violates naming convention, output names should match snake_case (lowercase alphanumeric characters and underscores).`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := testutil.RuleWithSpec(t, core.NamingConventionRule(), tt.spec)
+			issues := rule.Apply("main.tf", testutil.ParseToHcl(t, "main.tf", src))
+
+			var got []string
+			for _, issue := range issues {
+				got = append(got, issue.Message)
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("got messages\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestNameFormat_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		spec map[string]any
+	}{
+		{name: "unknown preset", spec: map[string]any{"preset": "SCREAMING_CASE"}},
+		{name: "unknown block type", spec: map[string]any{"patterns": map[string]any{"provider": "snake_case"}}},
+		{name: "invalid regex", spec: map[string]any{"patterns": map[string]any{"resource": "^[a-z"}}},
+		{name: "negative max length", spec: map[string]any{"max_length": -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := types.ParseRuleOptions(core.NamingConventionRule().Options(), tt.spec); err == nil {
+				t.Errorf("expected an error for %v", tt.spec)
+			}
+		})
+	}
+}
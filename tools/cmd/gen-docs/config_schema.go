@@ -0,0 +1,21 @@
+//go:build tfcoach_tools
+
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/Marcel2603/tfcoach/cmd/config"
+	"github.com/Marcel2603/tfcoach/rules/core"
+)
+
+func GenerateConfigSchema(filename string) {
+	schema, err := config.JSONSchema(core.All())
+	if err != nil {
+		log.Fatalf("failed to generate config schema: %v", err)
+	}
+	if err = os.WriteFile(filename, append(schema, '\n'), 0644); err != nil {
+		log.Fatalf("failed to write config schema: %v", err)
+	}
+}
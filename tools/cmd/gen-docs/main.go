@@ -11,6 +11,9 @@ func main() {
 	fmt.Println("Generate Rules Overview")
 	GenerateRulesOverview("docs/pages/rules/index.md")
 	fmt.Println("Rules Overview generated")
+	fmt.Println("Generate Config Schema")
+	GenerateConfigSchema("docs/pages/schemas/config.json")
+	fmt.Println("Config Schema generated")
 	fmt.Println("Generate Nav")
 	GenerateNav("docs/pages", "docs/zensical.toml")
 	fmt.Println("Nav generated")
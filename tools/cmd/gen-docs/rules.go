@@ -11,6 +11,7 @@ import (
 	"os"
 	"path"
 	"slices"
+	"strings"
 
 	"github.com/Marcel2603/tfcoach/internal/types"
 	"github.com/Marcel2603/tfcoach/rules/core"
@@ -42,6 +43,7 @@ func GenerateRulesOverview(filename string) {
 			}
 		}
 	}
+	buf.WriteString(getOptionsDescription(rules))
 	if err := os.WriteFile(filename, buf.Bytes(), 0644); err != nil {
 		log.Fatalf("failed to write rules overview: %v", err)
 	}
@@ -53,3 +55,37 @@ func getIgnorationDescription() string {
 		"- Add a comment on top of the file `# tfcoach-ignore-file: core.rule_id1,core.rule_id2`\n" +
 		"- Add a comment above the Terraform block to exclude the next block from issuing an error `# tfcoach-ignore: core.rule_id1,core.rule_id2`\n"
 }
+
+func getOptionsDescription(rules []types.Rule) string {
+	var buf bytes.Buffer
+	buf.WriteString("\n## Options\n")
+	buf.WriteString("Rules with options can be configured under `rules.<rule_id>.spec` in the configuration file. " +
+		"Unknown options and values of the wrong type are rejected when loading the configuration.\n")
+	for _, r := range rules {
+		options := core.OptionsByID(r.ID())
+		if len(options) == 0 {
+			continue
+		}
+		buf.WriteString(fmt.Sprintf("\n### %s\n\n", r.ID()))
+		buf.WriteString("| Option | Type | Default | Description |\n")
+		buf.WriteString("|--------|------|---------|-------------|\n")
+		for _, option := range options {
+			description := option.Description
+			if len(option.Allowed) > 0 {
+				description += fmt.Sprintf(" (one of `%s`)", strings.Join(option.Allowed, "`, `"))
+			}
+			buf.WriteString(fmt.Sprintf(
+				"| `%s` | `%s` | `%s` | %s |\n",
+				option.Name,
+				option.Type,
+				escapeTableCell(option.DefaultString()),
+				escapeTableCell(description),
+			))
+		}
+	}
+	return buf.String()
+}
+
+func escapeTableCell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}